@@ -9,4 +9,22 @@ type Configuration struct {
 	EnvName   string `toml:"envName,omitempty" mapstructure:"envName,omitempty"`
 	Insecure  bool   `toml:"insecure,omitempty" mapstructure:"insecure,omitempty"`
 	Plaintext bool   `toml:"plaintext,omitempty" mapstructure:"plaintext,omitempty"`
+	// CredentialStore is where the access token is kept: keyring, file or plaintext
+	CredentialStore string `toml:"credential_store,omitempty" mapstructure:"credential_store,omitempty"`
+	// CACert is the PEM file of the certificate authorities trusted for the backend, instead of the system ones
+	CACert string `toml:"ca_cert,omitempty" mapstructure:"ca_cert,omitempty"`
+	// ClientCert and ClientKey are the PEM files of the certificate presented to the backend for mutual TLS
+	ClientCert string `toml:"client_cert,omitempty" mapstructure:"client_cert,omitempty"`
+	ClientKey  string `toml:"client_key,omitempty" mapstructure:"client_key,omitempty"`
+	// TLSServerName overrides the name the certificate of the backend is verified against
+	TLSServerName string `toml:"tls_server_name,omitempty" mapstructure:"tls_server_name,omitempty"`
+	// MinTLSVersion is the lowest TLS version accepted: 1.2 or 1.3
+	MinTLSVersion string `toml:"min_tls_version,omitempty" mapstructure:"min_tls_version,omitempty"`
+	// ProxyURL is the http, https or socks5 proxy the backend is reached through, instead of HTTPS_PROXY
+	ProxyURL string `toml:"proxy_url,omitempty" mapstructure:"proxy_url,omitempty"`
+	// Timeout is the deadline of each single-response request to the backend, e.g. 30s, 0 for none. Streams following
+	// deployments, operations, logs and statuses are not bounded by it.
+	Timeout string `toml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+	// Retries is the number of times a request failing with a transient error is retried
+	Retries string `toml:"retries,omitempty" mapstructure:"retries,omitempty"`
 }
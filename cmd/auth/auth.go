@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"github.com/dream-horizon-org/odin/cmd"
+	"github.com/spf13/cobra"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authentication",
+	Long:  `Log in, log out and inspect the access token of the active profile`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(authCmd)
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var configureClient = service.Configure{}
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to odin",
+	Long:  `Re-run the auth provider flow for the active profile and store the new access token, keeping the backend settings untouched`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeLogin(cmd)
+	},
+}
+
+func init() {
+	loginCmd.Flags().Bool("no-browser", false, "authenticate with a device code instead of a local browser, e.g. over SSH or in CI")
+	authCmd.AddCommand(loginCmd)
+}
+
+func executeLogin(cmd *cobra.Command) error {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	if appConfig.BackendAddress == "" {
+		return exitcode.Errorf(exitcode.Validation, "Profile is not configured yet. Run `odin configure`")
+	}
+
+	noBrowser, err := cmd.Flags().GetBool("no-browser")
+	if err != nil {
+		return err
+	}
+
+	ctx := context.WithValue(cmd.Context(), constant.TraceIDKey, util.GenerateTraceID())
+	token, err := configureClient.Authenticate(&ctx, appConfig.OrgId, noBrowser)
+	if err != nil {
+		return exitcode.Wrap(err, "Failed to log in: ")
+	}
+	if err := config.UpdateAccessToken(token); err != nil {
+		return err
+	}
+
+	fmt.Println("\033[32mLogged in!\033[0m")
+	return nil
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out of odin",
+	Long:  `Remove the access token of the active profile`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.ClearAccessToken(); err != nil {
+			return err
+		}
+		profile, err := config.GetActiveProfile()
+		if err != nil {
+			return err
+		}
+		log.Infof("Logged out of profile [%s]", profile)
+		return nil
+	},
+}
+
+func init() {
+	authCmd.AddCommand(logoutCmd)
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	authProvider "github.com/dream-horizon-org/odin/internal/auth"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// authStatus is the authentication state of the active profile
+type authStatus struct {
+	Profile         string                 `json:"profile"`
+	OrgID           int64                  `json:"orgId"`
+	BackendAddress  string                 `json:"backendAddress"`
+	CredentialStore string                 `json:"credentialStore,omitempty"`
+	LoggedIn        bool                   `json:"loggedIn"`
+	ExpiresAt       *time.Time             `json:"expiresAt,omitempty"`
+	Expired         bool                   `json:"expired"`
+	Claims          map[string]interface{} `json:"claims,omitempty"`
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"whoami"},
+	Short:   "Show authentication status",
+	Long:    `Show the active profile, its organisation, backend and the expiry and claims of its access token. Exits non-zero when not logged in or the token has expired`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeStatus(cmd)
+	},
+}
+
+func init() {
+	authCmd.AddCommand(statusCmd)
+}
+
+func executeStatus(cmd *cobra.Command) error {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	profile, err := config.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	status := authStatus{
+		Profile:         profile,
+		OrgID:           appConfig.OrgId,
+		BackendAddress:  appConfig.BackendAddress,
+		CredentialStore: appConfig.CredentialStore,
+		LoggedIn:        appConfig.AccessToken != "",
+	}
+	if expiry, ok := authProvider.TokenExpiry(appConfig.AccessToken); ok {
+		status.ExpiresAt = &expiry
+		status.Expired = time.Now().After(expiry)
+	}
+	if claims, err := authProvider.TokenClaims(appConfig.AccessToken); err == nil {
+		status.Claims = claims
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	err = output.Print(outputFormat, status, func() {
+		printStatus(status)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !status.LoggedIn {
+		return exitcode.Errorf(exitcode.Auth, "Not logged in. Run `odin auth login`")
+	}
+	if status.Expired {
+		return exitcode.Errorf(exitcode.Auth, "Access token has expired. Run `odin auth login`")
+	}
+	return nil
+}
+
+func printStatus(status authStatus) {
+	fmt.Printf("profile: %s\n", status.Profile)
+	fmt.Printf("orgId: %d\n", status.OrgID)
+	fmt.Printf("backendAddress: %s\n", status.BackendAddress)
+	if status.CredentialStore != "" {
+		fmt.Printf("credentialStore: %s\n", status.CredentialStore)
+	}
+	if !status.LoggedIn {
+		fmt.Println("loggedIn: false (run `odin auth login`)")
+		return
+	}
+	fmt.Println("loggedIn: true")
+	if status.ExpiresAt != nil {
+		state := fmt.Sprintf("in %s", time.Until(*status.ExpiresAt).Round(time.Second))
+		if status.Expired {
+			state = "expired, run `odin auth login`"
+		}
+		fmt.Printf("expiresAt: %s (%s)\n", status.ExpiresAt.Local().Format(time.RFC3339), state)
+	}
+	if len(status.Claims) > 0 {
+		fmt.Println("claims:")
+		keys := make([]string, 0, len(status.Claims))
+		for key := range status.Claims {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("    %s: %v\n", key, status.Claims[key])
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/spf13/cobra"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Print the access token",
+	Long:  `Print the raw access token of the active profile so it can be piped to other tools`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appConfig, err := config.GetConfig()
+		if err != nil {
+			return err
+		}
+		token := appConfig.AccessToken
+		if token == "" {
+			return exitcode.Errorf(exitcode.Auth, "Not logged in. Run `odin auth login`")
+		}
+		fmt.Println(token)
+		return nil
+	},
+}
+
+func init() {
+	authCmd.AddCommand(tokenCmd)
+}
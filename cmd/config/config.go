@@ -0,0 +1,26 @@
+package config
+
+import (
+	"github.com/dream-horizon-org/odin/cmd"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and write profile configuration",
+	Long:  `Read and write any key of the active profile, or of the profile given by --profile`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(configCmd)
+}
+
+// targetProfile returns the profile passed with --profile, or empty for the active profile
+func targetProfile(cmd *cobra.Command) string {
+	if !cmd.Flags().Changed("profile") {
+		return ""
+	}
+	profile, _ := cmd.Flags().GetString("profile")
+	return profile
+}
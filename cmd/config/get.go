@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+
+	appConfig "github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Get a configuration value",
+	Long:  `Print the value of a key stored in the profile`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := appConfig.GetValue(targetProfile(cmd), args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(getCmd)
+}
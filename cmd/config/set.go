@@ -0,0 +1,25 @@
+package config
+
+import (
+	appConfig "github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long:  `Type check the value against the key and write it to the profile, creating the profile if it doesn't exist`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := appConfig.SetValue(targetProfile(cmd), args[0], args[1]); err != nil {
+			return err
+		}
+		log.Info(args[0], " set to [", args[1], "] successfully")
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(setCmd)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	appConfig "github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var unsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Unset a configuration value",
+	Long:  `Reset a key of the profile to its default`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := appConfig.UnsetValue(targetProfile(cmd), args[0]); err != nil {
+			return err
+		}
+		log.Info(args[0], " unset successfully")
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(unsetCmd)
+}
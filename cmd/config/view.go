@@ -0,0 +1,42 @@
+package config
+
+import (
+	appConfig "github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	"github.com/dream-horizon-org/odin/pkg/table"
+	"github.com/spf13/cobra"
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "View the effective configuration",
+	Long:  `Print the configuration merged from the config file, ODIN_* environment variables and flags, and where each value came from`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeView(cmd)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(viewCmd)
+}
+
+func executeView(cmd *cobra.Command) error {
+	settings, err := appConfig.View()
+	if err != nil {
+		return err
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	return output.Print(outputFormat, settings, func() {
+		tableHeaders := []string{"Key", "Value", "Source"}
+		var tableData [][]interface{}
+		for _, setting := range settings {
+			tableData = append(tableData, []interface{}{setting.Key, setting.Value, setting.Source})
+		}
+		table.Write(tableHeaders, tableData)
+	})
+}
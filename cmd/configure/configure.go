@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"time"
 
 	apiConfig "github.com/dream-horizon-org/odin/api/configuration"
 	"github.com/dream-horizon-org/odin/app"
 	"github.com/dream-horizon-org/odin/cmd"
-	"github.com/dream-horizon-org/odin/internal/auth"
 	"github.com/dream-horizon-org/odin/internal/service"
 	appConfig "github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/credential"
 	"github.com/dream-horizon-org/odin/pkg/dir"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/proxy"
+	"github.com/dream-horizon-org/odin/pkg/tlsconfig"
 	"github.com/dream-horizon-org/odin/pkg/util"
-	pb "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/auth/v1"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -26,16 +30,24 @@ var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "Configure odin",
 	Long:  "Configure odin using odin access key and odin secret access key",
-	Run: func(cmd *cobra.Command, args []string) {
-		execute(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute(cmd)
 	},
 }
 
 func init() {
 	configureCmd.Flags().String("backend-address", "", "odin backend address with port")
-	configureCmd.Flags().BoolP("insecure", "I", true, "odin insecure")
+	configureCmd.Flags().BoolP("insecure", "I", false, "skip verification of the certificate of the backend, not recommended")
 	configureCmd.Flags().BoolP("plaintext", "P", false, "skip tls verification")
 	configureCmd.Flags().Int64("org-id", 0, "organisation id")
+	configureCmd.Flags().String("credential-store", "", "where to keep the access token: keyring, file or plaintext (default keyring when available)")
+	configureCmd.Flags().String("ca-cert", "", "PEM file of the certificate authorities trusted for the backend, e.g. a private CA")
+	configureCmd.Flags().String("client-cert", "", "PEM file of the client certificate for mutual TLS")
+	configureCmd.Flags().String("client-key", "", "PEM file of the client key for mutual TLS")
+	configureCmd.Flags().String("tls-server-name", "", "name the certificate of the backend is verified against (default host of the backend address)")
+	configureCmd.Flags().String("min-tls-version", "", "minimum TLS version: 1.2 or 1.3 (default 1.2)")
+	configureCmd.Flags().String("proxy-url", "", "http, https or socks5 proxy to reach the backend through, e.g. socks5://localhost:1080 (default HTTPS_PROXY unless NO_PROXY matches)")
+	configureCmd.Flags().Bool("no-browser", false, "authenticate with a device code instead of a local browser, e.g. over SSH or in CI")
 
 	// Bind flags to viper for automatic precedence handling
 	if err := viper.BindPFlag("backend_address", configureCmd.Flags().Lookup("backend-address")); err != nil {
@@ -50,74 +62,188 @@ func init() {
 	if err := viper.BindPFlag("org_id", configureCmd.Flags().Lookup("org-id")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("credential_store", configureCmd.Flags().Lookup("credential-store")); err != nil {
+		panic(err)
+	}
+	for key, flag := range map[string]string{
+		"ca_cert":         "ca-cert",
+		"client_cert":     "client-cert",
+		"client_key":      "client-key",
+		"tls_server_name": "tls-server-name",
+		"min_tls_version": "min-tls-version",
+		"proxy_url":       "proxy-url",
+	} {
+		if err := viper.BindPFlag(key, configureCmd.Flags().Lookup(flag)); err != nil {
+			panic(err)
+		}
+	}
 
 	cmd.RootCmd.AddCommand(configureCmd)
 }
 
-func execute(cmd *cobra.Command) {
-	createConfigFileIfNotExist()
+func execute(cmd *cobra.Command) error {
+	if err := createConfigFileIfNotExist(); err != nil {
+		return err
+	}
 
 	// Read configurations from existing config file, env variables and flags in viper
-	appConfig.GetConfig()
-
-	if !viper.IsSet("backend_address") || viper.GetString("backend_address") == "" {
-		log.Fatalf("Required configuration not found. Please pass --backend-address flag or set environment variable ODIN_BACKEND_ADDRESS")
+	profileConfig, err := appConfig.GetConfig()
+	if err != nil {
+		return err
 	}
-	if !viper.IsSet("org_id") {
-		log.Fatalf("Required configuration not found. Please pass --org-id flag or set environment variable ODIN_ORG_ID")
+	timeout, err := appConfig.RequestTimeout(profileConfig)
+	if err != nil {
+		return err
 	}
 
-	// Collect user input and write base config to file against the active profile
-	baseConfig := &apiConfig.Configuration{
-		BackendAddress: viper.GetString("backend_address"),
-		OrgId:          viper.GetInt64("org_id"),
-		Insecure:       viper.GetBool("insecure"),
-		Plaintext:      viper.GetBool("plaintext"),
+	// Start from the active profile so that the settings of `odin config set` are kept, then overlay the flags
+	baseConfig, err := appConfig.GetProfileConfig()
+	if err != nil {
+		return err
+	}
+	overlayFlags(baseConfig)
+	if baseConfig.BackendAddress == "" {
+		return exitcode.Errorf(exitcode.Validation, "Required configuration not found. Please pass --backend-address flag")
+	}
+	if !viper.IsSet("org_id") && baseConfig.OrgId == 0 {
+		return exitcode.Errorf(exitcode.Validation, "Required configuration not found. Please pass --org-id flag")
+	}
+	if baseConfig.CredentialStore != "" {
+		if _, err := credential.New(baseConfig.CredentialStore); err != nil {
+			return exitcode.New(exitcode.Validation, err)
+		}
+	}
+	if err := validateTLS(baseConfig); err != nil {
+		return err
+	}
+	if err := checkProxy(cmd.Context(), baseConfig, timeout); err != nil {
+		return err
+	}
+	if err := appConfig.WriteConfig(baseConfig); err != nil {
+		return err
 	}
-	appConfig.WriteConfig(baseConfig)
 
 	ctx := cmd.Context()
 	traceID := util.GenerateTraceID()
 	contextWithTrace := context.WithValue(ctx, constant.TraceIDKey, traceID)
-	authProviderResponse, err := configureClient.GetAuthProvider(&contextWithTrace, &pb.GetAuthProviderRequest{
-		OrgId: &baseConfig.OrgId,
-	})
+	noBrowser, err := cmd.Flags().GetBool("no-browser")
 	if err != nil {
-		log.Fatalf("Failed to get auth provider: %v ", err)
+		return err
 	}
-
-	provider, err := auth.GetProvider(authProviderResponse.Type)
+	token, err := configureClient.Authenticate(&contextWithTrace, baseConfig.OrgId, noBrowser)
 	if err != nil {
-		log.Fatalf("Error getting auth provider: %v", err)
+		return exitcode.Wrap(err, "Failed to authenticate: ")
 	}
 
-	authData, err := provider.Authenticate(authProviderResponse.Data)
-	if err != nil {
-		log.Fatalf("Error authenticating: %v", err)
+	// Persist token to config file against the active profile
+	baseConfig.AccessToken = token
+	if err := appConfig.WriteConfig(baseConfig); err != nil {
+		return err
 	}
 
-	tokenResponse, err := configureClient.GetUserToken(&contextWithTrace, &pb.GetUserTokenRequest{
-		OrgId: &baseConfig.OrgId,
-		Data:  authData,
-	})
-	if err != nil {
-		util.LogGrpcError(err, "Failed to get token ")
+	fmt.Println("\033[32mConfigured!\033[0m")
+	return nil
+}
+
+// overlayFlags overrides the configuration with the flags set on the command line
+func overlayFlags(config *apiConfig.Configuration) {
+	for key, value := range map[string]*string{
+		"backend_address":  &config.BackendAddress,
+		"credential_store": &config.CredentialStore,
+		"ca_cert":          &config.CACert,
+		"client_cert":      &config.ClientCert,
+		"client_key":       &config.ClientKey,
+		"tls_server_name":  &config.TLSServerName,
+		"min_tls_version":  &config.MinTLSVersion,
+		"proxy_url":        &config.ProxyURL,
+	} {
+		if viper.IsSet(key) {
+			*value = viper.GetString(key)
+		}
+	}
+	for _, file := range []*string{&config.CACert, &config.ClientCert, &config.ClientKey} {
+		*file = absolutePath(*file)
 	}
+	if viper.IsSet("org_id") {
+		config.OrgId = viper.GetInt64("org_id")
+	}
+	if viper.IsSet("insecure") {
+		config.Insecure = viper.GetBool("insecure")
+	}
+	if viper.IsSet("plaintext") {
+		config.Plaintext = viper.GetBool("plaintext")
+	}
+}
 
-	// Persist token to config file against the active profile
-	baseConfig.AccessToken = tokenResponse.Token
-	appConfig.WriteConfig(baseConfig)
+// validateTLS loads the TLS settings of the configuration, and warns when certificate verification is disabled
+func validateTLS(config *apiConfig.Configuration) error {
+	if config.Plaintext {
+		if config.CACert != "" || config.ClientCert != "" || config.Insecure {
+			log.Warn("TLS is disabled by --plaintext, the TLS settings are ignored")
+		}
+		return nil
+	}
+	if config.Insecure && (config.CACert != "" || config.TLSServerName != "") {
+		return exitcode.Errorf(exitcode.Validation, "--insecure skips the certificate verification configured by --ca-cert and --tls-server-name, drop --insecure")
+	}
+	if _, err := tlsconfig.New(config); err != nil {
+		return err
+	}
+	if tlsconfig.SkipsVerification(config) {
+		log.Warn(tlsconfig.InsecureWarning(config))
+		log.Warn("Drop --insecure to verify the certificate of the backend")
+	}
+	return nil
+}
 
-	fmt.Println("\033[32mConfigured!\033[0m")
+// checkProxy verifies that the backend can be reached through the proxy of the configuration or HTTPS_PROXY within the
+// request timeout, 0 for none
+func checkProxy(ctx context.Context, config *apiConfig.Configuration, timeout time.Duration) error {
+	if config.ProxyURL != "" {
+		if _, err := proxy.ParseURL(config.ProxyURL); err != nil {
+			return err
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	proxyURL, err := proxy.Check(ctx, config.ProxyURL, config.BackendAddress)
+	if proxyURL == nil {
+		return err
+	}
+	if err != nil {
+		return exitcode.Errorf(exitcode.Unavailable, "Unable to reach %s through proxy %s: %v", config.BackendAddress, proxyURL.Redacted(), err)
+	}
+	log.Infof("Reached %s through proxy %s", config.BackendAddress, proxyURL.Redacted())
+	return nil
 }
 
-func createConfigFileIfNotExist() {
+// absolutePath keeps the certificate paths valid when odin runs from another directory
+func absolutePath(file string) string {
+	if file == "" {
+		return ""
+	}
+	absolute, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	return absolute
+}
+
+func createConfigFileIfNotExist() error {
 	dirPath := path.Join(os.Getenv("HOME"), "."+app.App.Name)
 	if err := dir.CreateDirIfNotExist(dirPath); err != nil {
-		log.Fatalf("Error creating the .%s folder: %v", app.App.Name, err)
+		return fmt.Errorf("error creating the .%s folder: %w", app.App.Name, err)
 	}
 	configPath := path.Join(dirPath, "config")
-	if err := dir.CreateFileIfNotExist(configPath); err != nil {
-		log.Fatal("Error creating the config file")
+	if err := dir.CreateFileIfNotExist(configPath, 0600); err != nil {
+		return fmt.Errorf("error creating the config file: %w", err)
+	}
+	// The config file may hold access tokens, keep it readable by the owner only
+	if err := os.Chmod(configPath, 0600); err != nil {
+		log.Warnf("Unable to restrict permissions of the config file: %v", err)
 	}
+	return nil
 }
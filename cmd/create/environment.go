@@ -1,29 +1,35 @@
 package create
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	"github.com/dream-horizon-org/odin/pkg/util"
+	providerAccount "github.com/dream-horizon-org/odin/proto/gen/go/dream11/oam/provideraccount/v1"
 	environmentProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var envName string
 var accounts string
 
 var environmentClient service.Environment
+var providerAccountClient service.ProviderAccount
 
 // environmentCmd represents the environment command
 var environmentCmd = &cobra.Command{
 	Use:   "env <name>",
 	Short: "Create environment",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		envName = args[0]
-		execute(cmd)
+		return execute(cmd)
 	},
 }
 
@@ -40,6 +46,35 @@ func validateAccounts(accounts string) error {
 	return nil
 }
 
+// checkAccountsExist checks that every account is a known cloud provider account
+func checkAccountsExist(ctx *context.Context, accountList []string) error {
+	response, err := providerAccountClient.GetProviderAccounts(ctx, &providerAccount.GetProviderAccountsRequest{
+		Name: accountList,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+			log.Warn("Unable to verify accounts, provider accounts are not supported by the backend")
+			return nil
+		}
+		return err
+	}
+
+	knownAccounts := map[string]bool{}
+	for _, accountResponse := range response.GetAccounts() {
+		knownAccounts[accountResponse.GetAccount().GetName()] = true
+	}
+	var unknownAccounts []string
+	for _, account := range accountList {
+		if !knownAccounts[account] {
+			unknownAccounts = append(unknownAccounts, account)
+		}
+	}
+	if len(unknownAccounts) > 0 {
+		return exitcode.Errorf(exitcode.NotFound, "unknown accounts [%s], run `odin list accounts` to see the available accounts", strings.Join(unknownAccounts, ", "))
+	}
+	return nil
+}
+
 func init() {
 	environmentCmd.Flags().StringVar(&accounts, "accounts", "", "list of comma separated cloud provider accounts")
 	err := environmentCmd.MarkFlagRequired("accounts")
@@ -49,18 +84,20 @@ func init() {
 	createCmd.AddCommand(environmentCmd)
 }
 
-func execute(cmd *cobra.Command) {
+func execute(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	// Validate accounts parameter
 	if err := validateAccounts(accounts); err != nil {
-		log.Fatal("Invalid accounts parameter: ", err)
+		return exitcode.Wrap(exitcode.New(exitcode.Validation, err), "Invalid accounts parameter: ")
+	}
+	accountList := util.SplitProviderAccount(accounts)
+	if err := checkAccountsExist(&ctx, accountList); err != nil {
+		return exitcode.Wrap(err, "Invalid accounts parameter: ")
 	}
 	err := environmentClient.CreateEnvironment(&ctx, &environmentProto.CreateEnvironmentRequest{
 		EnvName:  envName,
-		Accounts: util.SplitProviderAccount(accounts),
+		Accounts: accountList,
 	})
 
-	if err != nil {
-		util.LogGrpcError(err, "Failed to create environment: ")
-	}
+	return exitcode.Wrap(err, "Failed to create environment: ")
 }
@@ -2,7 +2,7 @@ package delete
 
 import (
 	"github.com/dream-horizon-org/odin/internal/service"
-	"github.com/dream-horizon-org/odin/pkg/util"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
 	"github.com/spf13/cobra"
 )
@@ -16,9 +16,9 @@ var environmentCmd = &cobra.Command{
 	Short: "Delete environment",
 	Long:  `Delete environment`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name = args[0]
-		execute(cmd)
+		return execute(cmd)
 	},
 }
 
@@ -26,13 +26,11 @@ func init() {
 	deleteCmd.AddCommand(environmentCmd)
 }
 
-func execute(cmd *cobra.Command) {
+func execute(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	err := environmentClient.DeleteEnvironment(&ctx, &environment.DeleteEnvironmentRequest{
 		EnvName: name,
 	})
 
-	if err != nil {
-		util.LogGrpcError(err, "Failed to delete environment:")
-	}
+	return exitcode.Wrap(err, "Failed to delete environment: ")
 }
@@ -2,14 +2,14 @@ package deploy
 
 import (
 	"context"
-	"encoding/json"
-	"os"
 
 	"github.com/dream-horizon-org/odin/internal/service"
 	"github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/definition"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/util"
-	serviceDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
 	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -25,67 +25,79 @@ var serviceCmd = &cobra.Command{
 	Args: func(cmd *cobra.Command, args []string) error {
 		return cobra.NoArgs(cmd, args)
 	},
-	Long: "Deploy service using files or service name",
-	Run: func(cmd *cobra.Command, args []string) {
-		execute(cmd)
+	Long: "Deploy service using files or service name. Defaults are read from " + config.ProjectFileName + " when present",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute(cmd)
 	},
 }
 
 func init() {
 	serviceCmd.Flags().StringVar(&env, "env", "", "environment for deploying the service")
-	serviceCmd.Flags().StringVar(&definitionFile, "file", "", "path to the service definition file")
-	serviceCmd.Flags().StringVar(&provisioningFile, "provisioning", "", "path to the provisioning file")
+	serviceCmd.Flags().StringVar(&definitionFile, "file", "", "path to the service definition file in JSON or YAML format")
+	serviceCmd.Flags().StringVar(&provisioningFile, "provisioning", "", "path to the provisioning file in JSON or YAML format")
+	service.AddLogDrainFlags(serviceCmd.Flags())
 	deployCmd.AddCommand(serviceCmd)
 }
 
-func execute(cmd *cobra.Command) {
-	env = config.EnsureEnvPresent(env)
+func execute(cmd *cobra.Command) error {
+	var err error
+	env, err = config.EnsureEnvPresent(env)
+	if err != nil {
+		return err
+	}
+	if definitionFile == "" {
+		definitionFile = config.GetProject().DefinitionFile()
+	}
+	if provisioningFile == "" {
+		provisioningFile = config.GetProject().ProvisioningFile()
+	}
+	if definitionFile == "" || provisioningFile == "" {
+		return exitcode.Errorf(exitcode.Validation, "definitionFile and provisioningFile are required.")
+	}
+
 	ctx := cmd.Context()
 	traceID := util.GenerateTraceID()
 	contextWithTrace := context.WithValue(ctx, constant.TraceIDKey, traceID)
 	verboseEnabled, err := cmd.Flags().GetBool(constant.VerboseFlag)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	outputFormat, err := output.ProgressFormat(cmd)
+	if err != nil {
+		return err
+	}
+	logDrain, err := service.LogDrainFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
 
 	contextWithTrace = context.WithValue(contextWithTrace, constant.VerboseEnabledKey, verboseEnabled)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.OutputFormatKey, outputFormat)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.LogDrainKey, logDrain)
 
-	if definitionFile != "" && provisioningFile != "" {
-		deploy(contextWithTrace)
-	} else {
-		log.Fatal("definitionFile and provisioningFile are required.")
-	}
+	return deploy(contextWithTrace)
 }
 
-func deploy(ctx context.Context) {
-	definitionData, err := os.ReadFile(definitionFile)
+func deploy(ctx context.Context) error {
+	definitionProto, provisioningProto, err := definition.Load(definitionFile, provisioningFile)
 	if err != nil {
-		log.Fatal("Error while reading definition file ", err)
-	}
-	var definitionProto serviceDto.ServiceDefinition
-	if err := json.Unmarshal(definitionData, &definitionProto); err != nil {
-		log.Fatalf("Error unmarshalling definition file: %v", err)
+		return exitcode.New(exitcode.Validation, err)
 	}
 
-	provisioningData, err := os.ReadFile(provisioningFile)
-	if err != nil {
-		log.Fatal("Error while reading provisioning file ", err)
-	}
-	var compProvConfigs []*serviceDto.ComponentProvisioningConfig
-	if err := json.Unmarshal(provisioningData, &compProvConfigs); err != nil {
-		log.Fatalf("Error unmarshalling provisioning file: %v", err)
+	// Validate locally before the backend round trip
+	violations := definition.Validate(definitionFile, definitionProto, provisioningFile, provisioningProto)
+	for _, violation := range violations {
+		log.Error(violation.String())
 	}
-	provisioningProto := &serviceDto.ProvisioningConfig{
-		ComponentProvisioningConfig: compProvConfigs,
+	if len(violations) > 0 {
+		return exitcode.Errorf(exitcode.Validation, "Validation failed with %d violation(s)", len(violations))
 	}
 
 	err = serviceClient.DeployService(&ctx, &serviceProto.DeployServiceRequest{
 		EnvName:            env,
-		ServiceDefinition:  &definitionProto,
+		ServiceDefinition:  definitionProto,
 		ProvisioningConfig: provisioningProto,
 	})
 
-	if err != nil {
-		util.LogGrpcError(err, "Failed to deploy service: ")
-	}
+	return exitcode.Wrap(err, "Failed to deploy service: ")
 }
@@ -0,0 +1,81 @@
+package describe
+
+import (
+	"fmt"
+
+	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	accountDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/oam/dto/v1"
+	providerAccount "github.com/dream-horizon-org/odin/proto/gen/go/dream11/oam/provideraccount/v1"
+	"github.com/spf13/cobra"
+)
+
+var accountName string
+var linked bool
+var providerAccountClient = service.ProviderAccount{}
+var accountCmd = &cobra.Command{
+	Use:   "account <name>",
+	Short: "Describe cloud provider account",
+	Args:  cobra.ExactArgs(1),
+	Long:  `Describe cloud provider account details including its services and linked accounts`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accountName = args[0]
+		return executeAccount(cmd)
+	},
+}
+
+func init() {
+	accountCmd.Flags().BoolVar(&linked, "linked", false, "fetch details of the linked accounts")
+	describeCmd.AddCommand(accountCmd)
+}
+
+func executeAccount(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	response, err := providerAccountClient.GetProviderAccount(&ctx, &providerAccount.GetProviderAccountRequest{
+		Name:                      accountName,
+		FetchLinkedAccountDetails: linked,
+	})
+	if err != nil {
+		return exitcode.Wrap(err, "Failed to describe account: ")
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	return output.Print(outputFormat, response, func() {
+		printAccountInfo(response)
+	})
+}
+
+func printAccountInfo(response *providerAccount.GetProviderAccountResponse) {
+	fmt.Printf("Describing Account: %s\n\n", response.GetAccount().GetName())
+	printAccount(response.GetAccount(), "")
+	if len(response.GetLinkedAccounts()) > 0 {
+		fmt.Printf("linkedAccounts:\n")
+		for _, linkedAccount := range response.GetLinkedAccounts() {
+			fmt.Printf("    - name: %s\n", linkedAccount.GetName())
+			printAccount(linkedAccount, "      ")
+		}
+	} else if len(response.GetAccount().GetLinkedProviderAccountIds()) > 0 {
+		fmt.Printf("linkedAccountIds:\n")
+		for _, id := range response.GetAccount().GetLinkedProviderAccountIds() {
+			fmt.Printf("    - %d\n", id)
+		}
+	}
+}
+
+func printAccount(account *accountDto.ProviderAccount, indent string) {
+	if indent == "" {
+		fmt.Printf("name: %s\n", account.GetName())
+	}
+	fmt.Printf("%sprovider: %s\n", indent, account.GetProvider())
+	fmt.Printf("%scategory: %s\n", indent, account.GetCategory())
+	fmt.Printf("%sdefault: %t\n", indent, account.GetDefault())
+	fmt.Printf("%sservices:\n", indent)
+	for _, svc := range account.GetServices() {
+		fmt.Printf("%s    - name: %s\n", indent, svc.GetName())
+		fmt.Printf("%s      category: %s\n", indent, svc.GetCategory())
+	}
+}
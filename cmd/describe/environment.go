@@ -3,15 +3,14 @@ package describe
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/dream-horizon-org/odin/internal/service"
-	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	v1 "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
 	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -24,9 +23,9 @@ var environmentCmd = &cobra.Command{
 	Short: "Describe environments",
 	Args:  cobra.ExactArgs(1),
 	Long:  `Describe  environment details`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name = args[0]
-		executeEnv(cmd)
+		return executeEnv(cmd)
 	},
 }
 
@@ -36,7 +35,7 @@ func init() {
 	describeCmd.AddCommand(environmentCmd)
 }
 
-func executeEnv(cmd *cobra.Command) {
+func executeEnv(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	params := map[string]string{}
 
@@ -52,30 +51,24 @@ func executeEnv(cmd *cobra.Command) {
 	})
 
 	if err != nil {
-		util.LogGrpcError(err, "\nFailed to describe environment: ")
-		os.Exit(1)
+		return exitcode.Wrap(err, "Failed to describe environment: ")
 	}
 
 	outputFormat, err := cmd.Flags().GetString("output")
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	writeOutput(response, outputFormat)
-}
-
-func writeOutput(response *environment.DescribeEnvironmentResponse, format string) {
-
-	switch format {
-	case constant.TEXT:
-		printEnvInfo(response)
-	case constant.JSON:
-		writeAsJSONEnvResponse(response)
-	default:
-		log.Fatal("Unknown output format: ", format)
+	var printErr error
+	err = output.Print(outputFormat, response, func() {
+		printErr = printEnvInfo(response)
+	})
+	if err != nil {
+		return err
 	}
+	return printErr
 }
 
-func printEnvInfo(response *environment.DescribeEnvironmentResponse) {
+func printEnvInfo(response *environment.DescribeEnvironmentResponse) error {
 	env := response.Environment
 
 	// Extracting necessary fields
@@ -102,7 +95,7 @@ func printEnvInfo(response *environment.DescribeEnvironmentResponse) {
 				customServicesOp = append(customServicesOp, "      components: \n")
 				componentBytes, err := json.MarshalIndent(svc.Components, "", "  ")
 				if err != nil {
-					log.Fatal("Failed to marshal services summary: ", err)
+					return fmt.Errorf("failed to marshal services summary: %w", err)
 				}
 				var formattedComponentData = string(componentBytes)
 				formattedComponentData, _ = util.ConvertJSONToYAML(formattedComponentData)
@@ -142,6 +135,7 @@ func printEnvInfo(response *environment.DescribeEnvironmentResponse) {
 	fmt.Printf("createdAt: \"%s\"\n", createdAt)
 	fmt.Printf("updatedAt: \"%s\"\n", updatedAt)
 	fmt.Printf("services:\n%s\n", strings.Join(services, "\n"))
+	return nil
 }
 
 func findValueByKey(val interface{}, key string) string {
@@ -175,39 +169,3 @@ func getClusterNames(information *v1.AccountInformation) []string {
 	}
 	return clusterNames
 }
-
-func writeAsJSONEnvResponse(response *environment.DescribeEnvironmentResponse) {
-	var environments []map[string]interface{}
-	env := response.Environment
-	var accountInfoList []string
-	for _, accountInfo := range env.AccountInformation {
-		accountInfoList = append(accountInfoList, accountInfo.ProviderAccountName)
-	}
-
-	var servicesSummary []map[string]interface{}
-	for _, svc := range env.Services {
-		serviceMap := map[string]interface{}{
-			"name":    svc.Name,
-			"version": svc.Version,
-			"status":  svc.Status,
-		}
-		if len(svc.Components) > 0 {
-			serviceMap["components"] = svc.Components
-		}
-		servicesSummary = append(servicesSummary, serviceMap)
-	}
-
-	environments = append(environments, map[string]interface{}{
-		"name":                  env.Name,
-		"state":                 env.Status,
-		"cloudProviderAccounts": accountInfoList,
-		"createdBy":             env.CreatedBy,
-		"updatedBy":             env.UpdatedBy,
-		"createdAt":             env.CreatedAt.AsTime().String(),
-		"updatedAt":             env.UpdatedAt.AsTime().String(),
-		"services":              servicesSummary,
-	})
-
-	output, _ := json.MarshalIndent(environments, "", "  ")
-	fmt.Print(string(output))
-}
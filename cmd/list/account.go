@@ -0,0 +1,65 @@
+package list
+
+import (
+	"strings"
+
+	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	providerAccount "github.com/dream-horizon-org/odin/proto/gen/go/dream11/oam/provideraccount/v1"
+	"github.com/spf13/cobra"
+)
+
+var providerAccountClient = service.ProviderAccount{}
+var accountCmd = &cobra.Command{
+	Use:     "accounts",
+	Aliases: []string{"account"},
+	Short:   "List cloud provider accounts",
+	Args: func(cmd *cobra.Command, args []string) error {
+		return cobra.NoArgs(cmd, args)
+	},
+	Long: `List all cloud provider accounts that can be used to create environments`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeAccounts(cmd)
+	},
+}
+
+func init() {
+	listCmd.AddCommand(accountCmd)
+}
+
+func executeAccounts(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	response, err := providerAccountClient.GetProviderAccounts(&ctx, &providerAccount.GetProviderAccountsRequest{
+		FetchLinkedAccountDetails: true,
+	})
+	if err != nil {
+		return exitcode.Wrap(err, "Failed to list accounts: ")
+	}
+
+	return output.PrintTable(cmd, response, response.GetAccounts(), accountColumns, nil)
+}
+
+// accountRow is a row of the accounts table
+type accountRow = providerAccount.GetProviderAccountResponse
+
+var accountColumns = []output.Column[*accountRow]{
+	{Header: "Name", Value: func(row *accountRow) interface{} { return row.GetAccount().GetName() }},
+	{Header: "Provider", Value: func(row *accountRow) interface{} { return row.GetAccount().GetProvider() }},
+	{Header: "Category", Value: func(row *accountRow) interface{} { return row.GetAccount().GetCategory() }},
+	{Header: "Default", Value: func(row *accountRow) interface{} { return row.GetAccount().GetDefault() }},
+	{Header: "Services", Value: func(row *accountRow) interface{} {
+		var services []string
+		for _, svc := range row.GetAccount().GetServices() {
+			services = append(services, svc.GetName())
+		}
+		return strings.Join(services, ",")
+	}},
+	{Header: "Linked Accounts", Value: func(row *accountRow) interface{} {
+		var linkedAccounts []string
+		for _, linkedAccount := range row.GetLinkedAccounts() {
+			linkedAccounts = append(linkedAccounts, linkedAccount.GetName())
+		}
+		return strings.Join(linkedAccounts, ",")
+	}},
+}
@@ -1,17 +1,13 @@
 package list
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
 	"strconv"
 
 	"github.com/dream-horizon-org/odin/internal/service"
-	"github.com/dream-horizon-org/odin/pkg/constant"
-	"github.com/dream-horizon-org/odin/pkg/table"
-	"github.com/dream-horizon-org/odin/pkg/util"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	dto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
 	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -26,8 +22,8 @@ var environmentCmd = &cobra.Command{
 		return cobra.NoArgs(cmd, args)
 	},
 	Long: `List all types of environments created by current user or all environments`,
-	Run: func(cmd *cobra.Command, args []string) {
-		execute(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute(cmd)
 	},
 }
 
@@ -37,7 +33,7 @@ func init() {
 	listCmd.AddCommand(environmentCmd)
 }
 
-func execute(cmd *cobra.Command) {
+func execute(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	response, err := environmentClient.ListEnvironments(&ctx, &environment.ListEnvironmentRequest{
 		Params: map[string]string{
@@ -46,52 +42,15 @@ func execute(cmd *cobra.Command) {
 	})
 
 	if err != nil {
-		util.LogGrpcError(err, "Failed to list environments: ")
-		os.Exit(1)
+		return exitcode.Wrap(err, "Failed to list environments: ")
 	}
 
-	outputFormat, err := cmd.Flags().GetString("output")
-	if err != nil {
-		log.Fatal(err)
-	}
-	writeOutput(response, outputFormat)
-}
-
-func writeOutput(response *environment.ListEnvironmentResponse, format string) {
-
-	switch format {
-	case constant.TEXT:
-		writeAsText(response)
-	case constant.JSON:
-		writeAsJSON(response)
-	default:
-		log.Fatal("Unknown output format: ", format)
-	}
+	return output.PrintTable(cmd, response, response.GetEnvironments(), environmentColumns, nil)
 }
 
-func writeAsText(response *environment.ListEnvironmentResponse) {
-	tableHeaders := []string{"Name", "State", "Account"}
-	var tableData [][]interface{}
-	for _, env := range response.Environments {
-		tableData = append(tableData, []interface{}{
-			env.Name,
-			env.State,
-			env.Account,
-		})
-	}
-
-	table.Write(tableHeaders, tableData)
-}
-
-func writeAsJSON(response *environment.ListEnvironmentResponse) {
-	var environments []map[string]interface{}
-	for _, env := range response.Environments {
-		environments = append(environments, map[string]interface{}{
-			"name":    env.Name,
-			"status":  env.State,
-			"account": env.Account,
-		})
-	}
-	output, _ := json.MarshalIndent(environments, "", "  ")
-	fmt.Print(string(output))
+var environmentColumns = []output.Column[*dto.EnvironmentSummary]{
+	{Header: "Name", Value: func(env *dto.EnvironmentSummary) interface{} { return env.GetName() }},
+	{Header: "State", Value: func(env *dto.EnvironmentSummary) interface{} { return env.GetState() }},
+	{Header: "Account", Value: func(env *dto.EnvironmentSummary) interface{} { return env.GetAccount() }},
+	{Header: "Created By", Wide: true, Value: func(env *dto.EnvironmentSummary) interface{} { return env.GetCreatedBy() }},
 }
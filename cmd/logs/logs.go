@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dream-horizon-org/odin/cmd"
+	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	logsProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+// timestampLayout is the layout used to print log timestamps
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+var serviceName string
+var componentName string
+var traceID string
+var follow bool
+var levels []string
+var timestamps bool
+
+var logsClient = service.Logs{}
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Fetch logs",
+	Args: func(cmd *cobra.Command, args []string) error {
+		return cobra.NoArgs(cmd, args)
+	},
+	Long: `Fetch logs of a deployment or operation using its trace id`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute(cmd)
+	},
+}
+
+func init() {
+	logsCmd.Flags().StringVar(&serviceName, "service", "", "name of the service")
+	logsCmd.Flags().StringVar(&componentName, "component", "", "name of the component")
+	logsCmd.Flags().StringVar(&traceID, "trace-id", "", "trace id of the deployment or operation")
+	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep streaming new logs")
+	logsCmd.Flags().StringSliceVar(&levels, "level", nil, "comma separated log levels to show, all levels are shown by default")
+	logsCmd.Flags().BoolVar(&timestamps, "timestamps", false, "show the timestamp of each log")
+	if err := logsCmd.MarkFlagRequired("trace-id"); err != nil {
+		log.Fatal("Error marking 'trace-id' flag as required:", err)
+	}
+	cmd.RootCmd.AddCommand(logsCmd)
+}
+
+func execute(cmd *cobra.Command) error {
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	var writer service.LogWriter
+	switch outputFormat {
+	case constant.TEXT:
+		writer = writeAsText
+	default:
+		// Logs are printed one by one, so table formats are rejected before fetching any
+		if err := output.ValidateRenderFormat(outputFormat); err != nil {
+			return err
+		}
+		writer = func(logMessage *logsProto.Log) {
+			if err := output.PrintItem(outputFormat, logMessage); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+
+	ctx := context.WithValue(cmd.Context(), constant.TraceIDKey, traceID)
+	request := &logsProto.GetLogsRequest{
+		TraceId: traceID,
+		Follow:  &follow,
+	}
+	if serviceName != "" {
+		request.ServiceName = &serviceName
+	}
+	if componentName != "" {
+		request.ComponentName = &componentName
+	}
+	options := service.LogOptions{
+		Filter: service.IncludeLevels(levels),
+		Write:  writer,
+	}
+
+	// Page through the logs until no new log is returned, or keep going when following
+	backoff := constant.RetryInitialBackoff
+	for {
+		searchAfterParams, err := logsClient.GetLogs(&ctx, request, options)
+		if err != nil {
+			return exitcode.Wrap(err, "Failed to fetch logs: ")
+		}
+		if !slices.Equal(searchAfterParams, request.GetSearchAfterParams()) {
+			request.SearchAfterParams = searchAfterParams
+			backoff = constant.RetryInitialBackoff
+			continue
+		}
+		if !follow {
+			return nil
+		}
+		// The followed stream closed without new logs, wait before asking again
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, constant.RetryMaxBackoff)
+	}
+}
+
+func writeAsText(logMessage *logsProto.Log) {
+	if timestamps && logMessage.Timestamp != nil {
+		fmt.Printf("%s %s\n", time.UnixMilli(logMessage.GetTimestamp()).Format(timestampLayout), logMessage.GetMessage())
+		return
+	}
+	fmt.Println(logMessage.GetMessage())
+}
@@ -4,15 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/dream-horizon-org/odin/internal/service"
 	"github.com/dream-horizon-org/odin/internal/ui"
+	"github.com/dream-horizon-org/odin/pkg/catalog"
 	"github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/table"
 	"github.com/dream-horizon-org/odin/pkg/util"
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
 	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
 	"github.com/fatih/color"
 	log "github.com/sirupsen/logrus"
@@ -26,7 +32,10 @@ var env string
 var operation string
 var options string
 var file string
+var catalogFile string
+var yes bool
 var componentClient = service.Component{}
+var environmentClient = service.Environment{}
 var operateComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "operate component",
@@ -34,27 +43,27 @@ var operateComponentCmd = &cobra.Command{
 		return cobra.NoArgs(cmd, args)
 	},
 	Long: `odin operate component [Options]`,
-	Run: func(cmd *cobra.Command, args []string) {
-		execute(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute(cmd)
 	},
 }
 
 func init() {
 	operateComponentCmd.Flags().StringVar(&name, "name", "", "name of the component")
-	operateComponentCmd.Flags().StringVar(&serviceName, "service", "", " name of the service in which the component is deployed")
+	operateComponentCmd.Flags().StringVar(&serviceName, "service", "", "name of the service in which the component is deployed (default service of "+config.ProjectFileName+")")
 	operateComponentCmd.Flags().StringVar(&env, "env", "", "name of the environment in which the service is deployed")
 	operateComponentCmd.Flags().StringVar(&operation, "operation", "", "name of the operation to performed on the component")
 	operateComponentCmd.Flags().StringVar(&options, "options", "{}", "options of the operation in JSON format")
 	operateComponentCmd.Flags().StringVar(&file, "file", "", "path of the file which contains the options for the operation in JSON format")
+	operateComponentCmd.Flags().StringVar(&catalogFile, "catalog", "", "path of the component catalog file used to validate the options (default ~/.odin/catalog.yaml if present)")
+	operateComponentCmd.Flags().BoolVarP(&yes, "yes", "y", false, "redeploy without asking for confirmation of the changes, required with -o json")
 	if err := operateComponentCmd.MarkFlagRequired("name"); err != nil {
 		log.Fatal("Error marking 'name' flag as required:", err)
 	}
-	if err := operateComponentCmd.MarkFlagRequired("service"); err != nil {
-		log.Fatal("Error marking 'service' flag as required:", err)
-	}
 	if err := operateComponentCmd.MarkFlagRequired("operation"); err != nil {
 		log.Fatal("Error marking 'operation' flag as required:", err)
 	}
+	service.AddLogDrainFlags(operateComponentCmd.Flags())
 	operateCmd.AddCommand(operateComponentCmd)
 }
 
@@ -80,18 +89,42 @@ func formatValue(value interface{}) string {
 	}
 }
 
-func execute(cmd *cobra.Command) {
-	env = config.EnsureEnvPresent(env)
+func execute(cmd *cobra.Command) error {
+	var err error
+	env, err = config.EnsureEnvPresent(env)
+	if err != nil {
+		return err
+	}
+	if serviceName == "" {
+		serviceName = config.GetProject().Service
+	}
+	if serviceName == "" {
+		return exitcode.Errorf(exitcode.Validation, "Please provide the service name using --service or set service in %s", config.ProjectFileName)
+	}
 
 	ctx := cmd.Context()
 	traceID := util.GenerateTraceID()
 	contextWithTrace := context.WithValue(ctx, constant.TraceIDKey, traceID)
 	verboseEnabled, err := cmd.Flags().GetBool(constant.VerboseFlag)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	outputFormat, err := output.ProgressFormat(cmd)
+	if err != nil {
+		return err
+	}
+	// The confirmation prompt would break the JSON output, which is one object per line
+	if operation == "redeploy" && outputFormat == constant.JSON && !yes {
+		return exitcode.Errorf(exitcode.Validation, "Please pass --yes to redeploy with -o json, the changes can't be confirmed interactively")
+	}
+	logDrain, err := service.LogDrainFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
 
 	contextWithTrace = context.WithValue(contextWithTrace, constant.VerboseEnabledKey, verboseEnabled)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.OutputFormatKey, outputFormat)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.LogDrainKey, logDrain)
 
 	//validate the variables
 	var optionsData map[string]interface{}
@@ -100,25 +133,30 @@ func execute(cmd *cobra.Command) {
 	isFilePresent := len(file) > 0
 
 	if isOptionsPresent && isFilePresent {
-		log.Fatal("You can provide either --options or --file but not both")
+		return exitcode.Errorf(exitcode.Validation, "You can provide either --options or --file but not both")
 	}
 
 	if isFilePresent {
 		parsedConfig, err := util.ParseFile(file)
 		if err != nil {
-			log.Fatal("Error while parsing file " + file + " : " + err.Error())
+			return exitcode.Errorf(exitcode.Validation, "Error while parsing file %s : %v", file, err)
 		}
 		optionsData = parsedConfig.(map[string]interface{})
 	} else {
 		err := json.Unmarshal([]byte(options), &optionsData)
 		if err != nil {
-			log.Fatal("Unable to parse JSON data " + err.Error())
+			return exitcode.Errorf(exitcode.Validation, "Unable to parse JSON data %v", err)
 		}
 	}
 
+	optionsData, err = applyOperationSchema(&contextWithTrace, optionsData)
+	if err != nil {
+		return err
+	}
+
 	config, err := structpb.NewStruct(optionsData)
 	if err != nil {
-		log.Fatal("error converting JSON to structpb.Struct: ", err)
+		return exitcode.Errorf(exitcode.Validation, "error converting JSON to structpb.Struct: %v", err)
 	}
 	//call operate component client
 	if operation == "redeploy" {
@@ -130,7 +168,7 @@ func execute(cmd *cobra.Command) {
 			Config:        config,
 		})
 		if err != nil {
-			log.Fatal("Failed to compare operation changes\n", err)
+			return exitcode.Wrap(err, "Failed to compare operation changes: ")
 		}
 		oldComponentValues := diffValues.OldValues
 		newComponentValues := diffValues.NewValues
@@ -166,31 +204,17 @@ func execute(cmd *cobra.Command) {
 					})
 				}
 			}
-			table.Write(tableHeaders, tableData)
-		}
-
-		var message string
-		if oldComponentValues == nil || len(oldComponentValues.Fields) == 0 {
-			message = "\nNo changes from previous deployment. Do you want to continue? [y/n]:"
-		} else {
-			message = "\nDo you want to proceed with the above command? [y/n]:"
-		}
-		allowedInputsSlice := []string{"y", "n"}
-		allowedInputs := make(map[string]struct{}, len(allowedInputsSlice))
-		for _, input := range allowedInputsSlice {
-			allowedInputs[input] = struct{}{}
-		}
-
-		inputHandler := ui.Input{}
-		val, err := inputHandler.AskWithConstraints(message, allowedInputs)
-
-		if err != nil {
-			log.Fatal(err.Error())
+			// Keep stdout for the progress of the operation when it's JSON
+			diffOutput := io.Writer(os.Stdout)
+			if outputFormat == constant.JSON {
+				diffOutput = os.Stderr
+			}
+			table.Fprint(diffOutput, tableHeaders, tableData)
 		}
-
-		if val != "y" {
-			log.Info("Aborting the operation")
-			return
+		if yes {
+			log.Info("Proceeding without confirmation as --yes is set")
+		} else if err := confirmRedeploy(oldComponentValues == nil || len(oldComponentValues.Fields) == 0); err != nil {
+			return err
 		}
 
 	}
@@ -203,10 +227,100 @@ func execute(cmd *cobra.Command) {
 		Config:               config,
 	})
 
+	return exitcode.Wrap(err, "Failed to operate on component: ")
+}
+
+// confirmRedeploy asks the user to confirm the changes of the redeploy, aborting unless confirmed
+func confirmRedeploy(noChanges bool) error {
+	var message string
+	if noChanges {
+		message = "\nNo changes from previous deployment. Do you want to continue? [y/n]:"
+	} else {
+		message = "\nDo you want to proceed with the above command? [y/n]:"
+	}
+	allowedInputsSlice := []string{"y", "n"}
+	allowedInputs := make(map[string]struct{}, len(allowedInputsSlice))
+	for _, input := range allowedInputsSlice {
+		allowedInputs[input] = struct{}{}
+	}
+
+	inputHandler := ui.Input{}
+	val, err := inputHandler.AskWithConstraints(message, allowedInputs)
+
 	if err != nil {
-		util.LogGrpcError(err, "Failed to operate on component: ")
+		return err
+	}
+
+	if val != "y" {
+		return exitcode.Errorf(exitcode.Aborted, "Aborting the operation")
+	}
+	return nil
+}
+
+// applyOperationSchema merges the operation defaults into the options and validates them against the operation schema
+func applyOperationSchema(ctx *context.Context, optionsData map[string]interface{}) (map[string]interface{}, error) {
+	catalogPath := catalogFile
+	if catalogPath == "" {
+		catalogPath = catalog.DefaultPath()
+	}
+	if catalogPath == "" {
+		log.Debug("No component catalog found, skipping options validation")
+		return optionsData, nil
 	}
 
+	components, err := catalog.Load(catalogPath)
+	if err != nil {
+		return nil, exitcode.New(exitcode.Validation, fmt.Errorf("error while reading component catalog: %w", err))
+	}
+
+	componentType, componentVersion, err := describeComponent(ctx)
+	if err != nil {
+		return nil, exitcode.Wrap(err, "Unable to validate options without the component type, failed to describe component: ")
+	}
+
+	operationDefinition, err := catalog.FindOperation(components, componentType, componentVersion, operation)
+	if err != nil {
+		log.Warnf("%v, skipping options validation", err)
+		return optionsData, nil
+	}
+
+	optionsData = catalog.ApplyDefaults(operationDefinition.GetDefaults(), optionsData)
+	violations, err := catalog.Validate(operationDefinition.GetSchema(), optionsData)
+	if err != nil {
+		return nil, fmt.Errorf("error while validating options: %w", err)
+	}
+	for _, violation := range violations {
+		log.Error(violation)
+	}
+	if len(violations) > 0 {
+		return nil, exitcode.Errorf(exitcode.Validation, "Invalid options for operation %s on component %s", operation, name)
+	}
+	return optionsData, nil
+}
+
+// describeComponent returns the type and version of the component deployed in the environment
+func describeComponent(ctx *context.Context) (string, string, error) {
+	response, err := environmentClient.DescribeEnvironment(ctx, &environment.DescribeEnvironmentRequest{
+		EnvName: env,
+		Params: map[string]string{
+			"service":   serviceName,
+			"component": name,
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	for _, svc := range response.GetEnvironment().GetServices() {
+		if svc.GetName() != serviceName {
+			continue
+		}
+		for _, component := range svc.GetComponents() {
+			if component.GetName() == name {
+				return component.GetType(), component.GetVersion(), nil
+			}
+		}
+	}
+	return "", "", exitcode.Errorf(exitcode.NotFound, "component %s not found in service %s in environment %s", name, serviceName, env)
 }
 
 func flattenMap(m map[string]interface{}, prefix string) map[string]interface{} {
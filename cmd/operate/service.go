@@ -7,6 +7,8 @@ import (
 	"github.com/dream-horizon-org/odin/internal/service"
 	"github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
 	log "github.com/sirupsen/logrus"
@@ -22,38 +24,56 @@ var operateServiceCmd = &cobra.Command{
 		return cobra.NoArgs(cmd, args)
 	},
 	Long: `odin operate service [Options]`,
-	Run: func(cmd *cobra.Command, args []string) {
-		executeOperateService(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeOperateService(cmd)
 	},
 }
 
 func init() {
-	operateServiceCmd.Flags().StringVar(&name, "name", "", "name of the service")
+	operateServiceCmd.Flags().StringVar(&name, "name", "", "name of the service (default service of "+config.ProjectFileName+")")
 	operateServiceCmd.Flags().StringVar(&env, "env", "", "name of the environment in which the service is deployed")
 	operateServiceCmd.Flags().StringVar(&operation, "operation", "", "name of the operation to performed on the service")
 	operateServiceCmd.Flags().StringVar(&options, "options", "{}", "options of the operation in JSON format")
 	operateServiceCmd.Flags().StringVar(&file, "file", "", "path of the file which contains the options for the operation in JSON format")
-	if err := operateServiceCmd.MarkFlagRequired("name"); err != nil {
-		log.Fatal("Error marking 'name' flag as required:", err)
-	}
 	if err := operateServiceCmd.MarkFlagRequired("operation"); err != nil {
 		log.Fatal("Error marking 'operation' flag as required:", err)
 	}
+	service.AddLogDrainFlags(operateServiceCmd.Flags())
 	operateCmd.AddCommand(operateServiceCmd)
 }
 
-func executeOperateService(cmd *cobra.Command) {
-	env = config.EnsureEnvPresent(env)
+func executeOperateService(cmd *cobra.Command) error {
+	var err error
+	env, err = config.EnsureEnvPresent(env)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = config.GetProject().Service
+	}
+	if name == "" {
+		return exitcode.Errorf(exitcode.Validation, "Please provide the service name using --name or set service in %s", config.ProjectFileName)
+	}
 
 	ctx := cmd.Context()
 	traceID := util.GenerateTraceID()
 	contextWithTrace := context.WithValue(ctx, constant.TraceIDKey, traceID)
 	verboseEnabled, err := cmd.Flags().GetBool(constant.VerboseFlag)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	outputFormat, err := output.ProgressFormat(cmd)
+	if err != nil {
+		return err
+	}
+	logDrain, err := service.LogDrainFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
 
 	contextWithTrace = context.WithValue(contextWithTrace, constant.VerboseEnabledKey, verboseEnabled)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.OutputFormatKey, outputFormat)
+	contextWithTrace = context.WithValue(contextWithTrace, constant.LogDrainKey, logDrain)
 
 	//validate the variables
 	var optionsData map[string]interface{}
@@ -62,25 +82,25 @@ func executeOperateService(cmd *cobra.Command) {
 	isFilePresent := len(file) > 0
 
 	if isOptionsPresent && isFilePresent {
-		log.Fatal("You can provide either --options or --file but not both")
+		return exitcode.Errorf(exitcode.Validation, "You can provide either --options or --file but not both")
 	}
 
 	if isFilePresent {
 		parsedConfig, err := util.ParseFile(file)
 		if err != nil {
-			log.Fatal("Error while parsing file " + file + " : " + err.Error())
+			return exitcode.Errorf(exitcode.Validation, "Error while parsing file %s : %v", file, err)
 		}
 		optionsData = parsedConfig.(map[string]interface{})
 	} else {
 		err := json.Unmarshal([]byte(options), &optionsData)
 		if err != nil {
-			log.Fatal("Unable to parse JSON data " + err.Error())
+			return exitcode.Errorf(exitcode.Validation, "Unable to parse JSON data %v", err)
 		}
 	}
 
 	config, err := structpb.NewStruct(optionsData)
 	if err != nil {
-		log.Fatal("error converting JSON to structpb.Struct: ", err)
+		return exitcode.Errorf(exitcode.Validation, "error converting JSON to structpb.Struct: %v", err)
 	}
 
 	//call operate service client
@@ -92,8 +112,5 @@ func executeOperateService(cmd *cobra.Command) {
 		Config:               config,
 	})
 
-	if err != nil {
-		util.LogGrpcError(err, "\nFailed to operate on service: ")
-	}
-
+	return exitcode.Wrap(err, "Failed to operate on service: ")
 }
@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var copyCmd = &cobra.Command{
+	Use:   "copy <src> <dst>",
+	Short: "Copy a profile",
+	Long:  `Copy the configuration and access token of a profile to a new profile`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.CopyProfile(args[0], args[1]); err != nil {
+			return err
+		}
+		log.Info("profile [", args[0], "] copied to [", args[1], "] successfully")
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(copyCmd)
+}
@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Long:  `Delete a profile and its access token. The active profile can't be deleted`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.DeleteProfile(args[0]); err != nil {
+			return err
+		}
+		log.Info("profile [", args[0], "] deleted successfully")
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(deleteCmd)
+}
@@ -0,0 +1,61 @@
+package profile
+
+import (
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	"github.com/dream-horizon-org/odin/pkg/table"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Long:  `List the configured profiles, marking the active one`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeList(cmd)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(listCmd)
+}
+
+func executeList(cmd *cobra.Command) error {
+	names, active, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+	var profiles []profileView
+	for _, name := range names {
+		profile, err := config.GetProfile(name)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, newProfileView(name, active, profile))
+	}
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	return output.Print(outputFormat, profiles, func() {
+		tableHeaders := []string{"", "Name", "Backend Address", "Org Id", "Env", "Credential Store"}
+		var tableData [][]interface{}
+		for _, profile := range profiles {
+			marker := ""
+			if profile.Active {
+				marker = "*"
+			}
+			tableData = append(tableData, []interface{}{
+				marker,
+				profile.Name,
+				profile.BackendAddress,
+				profile.OrgID,
+				profile.EnvName,
+				profile.CredentialStore,
+			})
+		}
+		table.Write(tableHeaders, tableData)
+	})
+}
@@ -0,0 +1,17 @@
+package profile
+
+import (
+	"github.com/dream-horizon-org/odin/cmd"
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles",
+	Long:  `List, inspect, copy, rename and delete the profiles of the config file`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(profileCmd)
+}
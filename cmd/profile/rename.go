@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/dream-horizon-org/odin/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <name> <new-name>",
+	Short: "Rename a profile",
+	Long:  `Rename a profile, moving its access token and keeping it active if it was`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.RenameProfile(args[0], args[1]); err != nil {
+			return err
+		}
+		log.Info("profile [", args[0], "] renamed to [", args[1], "] successfully")
+		return nil
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(renameCmd)
+}
@@ -0,0 +1,85 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// redactedToken replaces the access token in the output
+const redactedToken = "REDACTED"
+
+// profileView is the printable form of a profile with its access token redacted
+type profileView struct {
+	Name            string `json:"name"`
+	Active          bool   `json:"active"`
+	BackendAddress  string `json:"backendAddress"`
+	OrgID           int64  `json:"orgId"`
+	EnvName         string `json:"envName,omitempty"`
+	Insecure        bool   `json:"insecure"`
+	Plaintext       bool   `json:"plaintext"`
+	CredentialStore string `json:"credentialStore,omitempty"`
+	AccessToken     string `json:"accessToken,omitempty"`
+}
+
+func newProfileView(name, active string, profile *configuration.Configuration) profileView {
+	view := profileView{
+		Name:            name,
+		Active:          name == active,
+		BackendAddress:  profile.BackendAddress,
+		OrgID:           profile.OrgId,
+		EnvName:         profile.EnvName,
+		Insecure:        profile.Insecure,
+		Plaintext:       profile.Plaintext,
+		CredentialStore: profile.CredentialStore,
+	}
+	if profile.AccessToken != "" {
+		view.AccessToken = redactedToken
+	}
+	return view
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a profile",
+	Long:  `Show the configuration of a profile with its access token redacted`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return executeShow(cmd, args[0])
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(showCmd)
+}
+
+func executeShow(cmd *cobra.Command, name string) error {
+	profile, err := config.GetProfile(name)
+	if err != nil {
+		return err
+	}
+	_, active, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+	view := newProfileView(name, active, profile)
+
+	outputFormat, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	return output.Print(outputFormat, view, func() {
+		fmt.Printf("name: %s\n", view.Name)
+		fmt.Printf("active: %t\n", view.Active)
+		fmt.Printf("backendAddress: %s\n", view.BackendAddress)
+		fmt.Printf("orgId: %d\n", view.OrgID)
+		fmt.Printf("envName: %s\n", view.EnvName)
+		fmt.Printf("insecure: %t\n", view.Insecure)
+		fmt.Printf("plaintext: %t\n", view.Plaintext)
+		fmt.Printf("credentialStore: %s\n", view.CredentialStore)
+		fmt.Printf("accessToken: %s\n", view.AccessToken)
+	})
+}
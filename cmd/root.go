@@ -3,6 +3,10 @@ package cmd
 import (
 	"os"
 
+	"github.com/dream-horizon-org/odin/internal/service"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,25 +16,79 @@ import (
 var RootCmd = &cobra.Command{
 	Use:   "odin",
 	Short: "Interface for service definitions & deployments into self-managed environments",
-	Long:  `Deploy services in environments`,
+	Long: `Deploy services in environments
+
+Exit codes:
+  0  success
+  1  any other error
+  2  invalid flags, arguments, files or configuration
+  3  not logged in, expired token or permission denied
+  4  environment, service, component or profile not found
+  5  conflict with an existing resource or a running action
+  6  Odin backend unavailable
+  7  deployment or operation FAILED
+  8  aborted by the user`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.LoadProject()
+	},
 }
 
 func init() {
 	RootCmd.PersistentFlags().StringP("profile", "p", "default", "odin profile")
-	RootCmd.PersistentFlags().StringP("output", "o", "text", "odin output format")
+	RootCmd.PersistentFlags().StringP("output", "o", "text", "output format: text, wide, json, yaml, jsonpath=..., go-template=..., template-file=... or custom-columns=...")
+	RootCmd.PersistentFlags().String("sort-by", "", "sort table output by a JSONPath expression, e.g. .name")
+	RootCmd.PersistentFlags().Bool("no-headers", false, "print table output without headers")
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "odin verbose logging")
+	RootCmd.PersistentFlags().Duration("timeout", constant.RequestTimeout, "deadline of each single-response request to the backend, 0 for none, overrides the timeout of the profile. Streams following deployments, operations, logs and statuses are not bounded by it")
+	RootCmd.PersistentFlags().Int("retries", constant.MaxRetries, "number of retries of a request failing with a transient error, overrides the retries of the profile")
 	err := viper.BindPFlag("profile", RootCmd.PersistentFlags().Lookup("profile"))
 	if err != nil {
 		log.Fatal("Error while binding profile flag")
 	}
+	config.BindFlag("profile", RootCmd.PersistentFlags().Lookup("profile"))
+	config.BindFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout"))
+	config.BindFlag("retries", RootCmd.PersistentFlags().Lookup("retries"))
 	viper.SetDefault("profile", "default")
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.Errorf(exitcode.Validation, "%v\nRun '%s --help' for usage", err, cmd.CommandPath())
+	})
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Commands return their errors, which are logged here before exiting with the exit code of the error.
 func Execute() {
+	validationErrors(RootCmd)
 	err := RootCmd.Execute()
+	service.CloseConnection()
 	if err != nil {
-		os.Exit(1)
+		log.Error(err)
+		os.Exit(exitcode.FromError(err))
+	}
+}
+
+// validationErrors makes invalid arguments and missing required flags of the command and its subcommands exit with
+// the validation exit code
+func validationErrors(cmd *cobra.Command) {
+	for _, child := range cmd.Commands() {
+		validationErrors(child)
+	}
+	if cmd.HasSubCommands() && cmd.Args == nil {
+		return
+	}
+	validateArgs := cmd.Args
+	if validateArgs == nil {
+		validateArgs = cobra.ArbitraryArgs
+	}
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		if err := validateArgs(cmd, args); err != nil {
+			return exitcode.New(exitcode.Validation, err)
+		}
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return exitcode.New(exitcode.Validation, err)
+		}
+		return exitcode.New(exitcode.Validation, cmd.ValidateFlagGroups())
 	}
 }
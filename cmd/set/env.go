@@ -2,7 +2,7 @@ package set
 
 import (
 	"github.com/dream-horizon-org/odin/pkg/config"
-	log "github.com/sirupsen/logrus"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	"github.com/spf13/cobra"
 )
 
@@ -11,11 +11,11 @@ var setEnvCmd = &cobra.Command{
 	Use:   "env",
 	Short: "odin set default environment",
 	Long:  `modify environment in config file`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
-			log.Fatal("Error: need one more parameter for environment name")
+			return exitcode.Errorf(exitcode.Validation, "Error: need one more parameter for environment name")
 		}
-		setEnvironment(args[0])
+		return setEnvironment(args[0])
 	},
 }
 
@@ -23,6 +23,6 @@ func init() {
 	setCmd.AddCommand(setEnvCmd)
 }
 
-func setEnvironment(envName string) {
-	config.UpdateEnvName(envName)
+func setEnvironment(envName string) error {
+	return config.UpdateEnvName(envName)
 }
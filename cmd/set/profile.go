@@ -12,9 +12,12 @@ var profileCmd = &cobra.Command{
 	Short: "set profile",
 	Long:  `modify profile in config file`,
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		config.SetProfile(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.SetProfile(args[0]); err != nil {
+			return err
+		}
 		log.Info("profile set to [", args[0], "] successfully")
+		return nil
 	},
 }
 
@@ -1,13 +1,13 @@
 package status
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/dream-horizon-org/odin/internal/service"
-	"github.com/dream-horizon-org/odin/pkg/constant"
-	"github.com/dream-horizon-org/odin/pkg/table"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
 	"github.com/spf13/cobra"
@@ -15,13 +15,20 @@ import (
 
 // setstatusCmd represents the env command
 var setstatusCmd = &cobra.Command{
-	Use:   "env <envName>",
+	Use:   "env [envName]",
 	Short: "Fetch status of the environment",
-	Long:  `Fetch status of the environment`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		envName = args[0]
-		getStatus(cmd)
+	Long:  "Fetch status of the environment. The environment and service default to the ones of " + config.ProjectFileName + " when present. With --watch the status is followed until every service reaches a terminal state",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			envName = args[0]
+		}
+		var err error
+		envName, err = config.EnsureEnvPresent(envName)
+		if err != nil {
+			return err
+		}
+		return getStatus(cmd)
 	},
 }
 var environmentClient = service.Environment{}
@@ -31,86 +38,75 @@ var envName string
 func init() {
 	statusCmd.AddCommand(setstatusCmd)
 	setstatusCmd.Flags().String("service", "", "Name of the service (optional)")
+	setstatusCmd.Flags().BoolVarP(&watch, "watch", "w", false, "keep watching the status until every service reaches a terminal state")
+	setstatusCmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "time to wait before polling again when the status stream closes while watching")
 }
 
-func getStatus(cmd *cobra.Command) {
+func getStatus(cmd *cobra.Command) error {
 	ctx := cmd.Context()
 	serviceName, _ = cmd.Flags().GetString("service")
-	if envName == "" {
-		log.Fatal("Error: --name is required")
+	if serviceName == "" {
+		serviceName = config.GetProject().Service
 	}
-	response, err := environmentClient.EnvironmentStatus(&ctx, &environment.StatusEnvironmentRequest{
+	request := &environment.StatusEnvironmentRequest{
 		EnvName:     envName,
 		ServiceName: serviceName,
-	})
-	if err != nil {
-		util.LogGrpcError(err, "Failed to get environment status: ")
 	}
-	outputFormat, err := cmd.Flags().GetString("output")
+	if watch {
+		return watchStatus(cmd, request)
+	}
+	response, err := environmentClient.EnvironmentStatus(&ctx, request)
 	if err != nil {
-		log.Fatal(err)
+		return exitcode.Wrap(err, "Failed to get environment status: ")
+	}
+	if serviceName == "" {
+		return output.PrintTable(cmd, response, response.GetServicesStatus(), serviceColumns, func() {
+			printEnvironmentDetails(response)
+			fmt.Println("\nServices:")
+		})
 	}
-	writeOutput(response, outputFormat)
-}
-
-func writeOutput(response *environment.StatusEnvironmentResponse, format string) {
 
-	switch format {
-	case constant.TEXT:
-		writeAsTextEnvResponse(response)
-	case constant.JSON:
-		writeAsJSONEnvResponse(response)
-	default:
-		log.Fatal("Unknown output format: ", format)
+	var selected *environment.DeployedServiceStatus
+	for _, svc := range response.GetServicesStatus() {
+		if svc.GetServiceName() == serviceName {
+			selected = svc
+		}
 	}
+	return output.PrintTable(cmd, response, selected.GetComponentStatus(), componentColumns, func() {
+		printEnvironmentDetails(response)
+		fmt.Printf("Fetching status for service: %s in environment: %s\n", serviceName, envName)
+		if selected != nil {
+			fmt.Printf("Service version: %s\n", selected.GetServiceVersion())
+			fmt.Printf("Service Status: %s\n", selected.GetServiceStatus())
+			fmt.Printf("Last deployed: %s\n", util.FormatToHumanReadableDuration(selected.GetLastDeployed()))
+		}
+		fmt.Println("Component details:")
+	})
 }
 
-func writeAsTextEnvResponse(response *environment.StatusEnvironmentResponse) {
+func printEnvironmentDetails(response *environment.StatusEnvironmentResponse) {
 	fmt.Printf("Fetching status for environment: %s\n", response.GetEnvName())
 	fmt.Printf("Environment Status: %s\n", response.GetEnvStatus())
-	var tableHeaders = []string{"NAME",
-		"VERSION",
-		"STATUS",
-		"LAST DEPLOYED",
-	}
-	var tableData [][]interface{}
+}
 
-	if serviceName == "" {
-		fmt.Println("\nServices:")
-		for _, svc := range response.GetServicesStatus() {
-			tableData = append(tableData, []interface{}{
-				svc.GetServiceName(),
-				svc.GetServiceVersion(),
-				svc.GetServiceStatus(),
-				util.FormatToHumanReadableDuration(svc.GetLastDeployed()),
-			})
-		}
-	} else {
-		tableHeaders = []string{"NAME",
-			"STATUS"}
-		fmt.Printf("Fetching status for service: %s in environment: %s\n", serviceName, envName)
-		for _, svc := range response.GetServicesStatus() {
-			if svc.GetServiceName() == serviceName {
-				fmt.Printf("Service version: %s\n", svc.GetServiceVersion())
-				fmt.Printf("Service Status: %s\n", svc.GetServiceStatus())
-				fmt.Printf("Last deployed: %s\n", util.FormatToHumanReadableDuration(svc.GetLastDeployed()))
-				fmt.Println("Component details:")
-				for _, component := range svc.GetComponentStatus() {
-					tableData = append(tableData, []interface{}{
-						component.GetComponentName(),
-						component.GetComponentStatus(),
-					})
-				}
-			}
-		}
-	}
-	table.Write(tableHeaders, tableData)
+var serviceColumns = []output.Column[*environment.DeployedServiceStatus]{
+	{Header: "NAME", Value: func(svc *environment.DeployedServiceStatus) interface{} { return svc.GetServiceName() }},
+	{Header: "VERSION", Value: func(svc *environment.DeployedServiceStatus) interface{} { return svc.GetServiceVersion() }},
+	{Header: "STATUS", Value: func(svc *environment.DeployedServiceStatus) interface{} { return svc.GetServiceStatus() }},
+	{Header: "LAST DEPLOYED", Value: func(svc *environment.DeployedServiceStatus) interface{} {
+		return util.FormatToHumanReadableDuration(svc.GetLastDeployed())
+	}},
+	{Header: "COMPONENTS", Wide: true, Value: func(svc *environment.DeployedServiceStatus) interface{} {
+		return len(svc.GetComponentStatus())
+	}},
 }
 
-func writeAsJSONEnvResponse(response *environment.StatusEnvironmentResponse) {
-	output, err := json.MarshalIndent(response, "", "  ")
-	if err != nil {
-		log.Fatal("Error marshaling JSON:", err)
-	}
-	fmt.Print(string(output))
+var componentColumns = []output.Column[*environment.StatusEnvComponentStatus]{
+	{Header: "NAME", Value: func(component *environment.StatusEnvComponentStatus) interface{} { return component.GetComponentName() }},
+	{Header: "VERSION", Wide: true, Value: func(component *environment.StatusEnvComponentStatus) interface{} {
+		return component.GetComponentVersion()
+	}},
+	{Header: "STATUS", Value: func(component *environment.StatusEnvComponentStatus) interface{} {
+		return component.GetComponentStatus()
+	}},
 }
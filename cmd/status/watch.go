@@ -0,0 +1,117 @@
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/envstatus"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
+	"github.com/dream-horizon-org/odin/pkg/table"
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	"github.com/fatih/color"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var watch bool
+var interval time.Duration
+
+// statusView redraws the status of the environment in place on a terminal
+type statusView struct {
+	// lines is the number of lines drawn last time, which are cleared before drawing again
+	lines int
+}
+
+// watchStatus streams the status of the environment until every service reaches a terminal state. The table is
+// redrawn in place on a terminal, otherwise every change is printed as a JSON event per line.
+func watchStatus(cmd *cobra.Command, request *environment.StatusEnvironmentRequest) error {
+	outputFormat, err := output.ProgressFormat(cmd)
+	if err != nil {
+		return err
+	}
+	interactive := outputFormat == constant.TEXT && term.IsTerminal(int(os.Stdout.Fd()))
+
+	ctx := cmd.Context()
+	encoder := json.NewEncoder(os.Stdout)
+	view := &statusView{}
+	var previous *environment.StatusEnvironmentResponse
+	for {
+		err := environmentClient.WatchEnvironmentStatus(&ctx, request, func(response *environment.StatusEnvironmentResponse) {
+			events := envstatus.Changes(previous, response, time.Now().UTC())
+			previous = response
+			if interactive {
+				view.draw(response, events)
+				return
+			}
+			for _, event := range events {
+				if err := encoder.Encode(event); err != nil {
+					log.Errorf("Unable to write status event: %v", err)
+				}
+			}
+		})
+		if err != nil {
+			return exitcode.Wrap(err, "Failed to get environment status: ")
+		}
+		if envstatus.Settled(previous) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// draw replaces the previously drawn status with the response, highlighting the statuses which just changed
+func (v *statusView) draw(response *environment.StatusEnvironmentResponse, events []envstatus.Event) {
+	changed := map[string]bool{}
+	for _, event := range events {
+		if event.PreviousStatus != "" {
+			changed[event.Key()] = true
+		}
+	}
+	highlight := func(key, status string) string {
+		if changed[key] {
+			return color.YellowString(status)
+		}
+		return status
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Environment: %s\n", response.GetEnvName())
+	fmt.Fprintf(&buffer, "Environment Status: %s\n", highlight(envstatus.Key("", ""), response.GetEnvStatus()))
+	fmt.Fprintf(&buffer, "Updated at: %s\n\n", time.Now().Format(time.TimeOnly))
+	var tableData [][]interface{}
+	for _, svc := range response.GetServicesStatus() {
+		tableData = append(tableData, []interface{}{
+			svc.GetServiceName(),
+			"",
+			svc.GetServiceVersion(),
+			highlight(envstatus.Key(svc.GetServiceName(), ""), svc.GetServiceStatus()),
+		})
+		for _, component := range svc.GetComponentStatus() {
+			tableData = append(tableData, []interface{}{
+				"",
+				component.GetComponentName(),
+				component.GetComponentVersion(),
+				highlight(envstatus.Key(svc.GetServiceName(), component.GetComponentName()), component.GetComponentStatus()),
+			})
+		}
+	}
+	table.Fprint(&buffer, []string{"SERVICE", "COMPONENT", "VERSION", "STATUS"}, tableData)
+
+	if v.lines > 0 {
+		// move the cursor to the start of the previous drawing and clear everything below it
+		fmt.Printf("\033[%dA\033[J", v.lines)
+	}
+	fmt.Print(buffer.String())
+	v.lines = strings.Count(buffer.String(), "\n")
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/dream-horizon-org/odin/internal/service"
 	"github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/output"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
 	log "github.com/sirupsen/logrus"
@@ -19,43 +21,64 @@ var envName string
 var serviceClient = service.Service{}
 
 var serviceCmd = &cobra.Command{
-	Use:   "service <name>",
+	Use:   "service [name]",
 	Short: "Undeploy service",
-	Long:  `Undeploy service`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		name = args[0]
-		execute(cmd)
+	Long:  "Undeploy service. The service name defaults to the one of " + config.ProjectFileName + " when present",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name = config.GetProject().Service
+		if len(args) > 0 {
+			name = args[0]
+		}
+		if name == "" {
+			return exitcode.Errorf(exitcode.Validation, "Please provide the service name as an argument or set service in %s", config.ProjectFileName)
+		}
+		return execute(cmd)
 	},
 }
 
 func init() {
 	serviceCmd.Flags().StringVar(&envName, "env", "", "name of the env")
+	service.AddLogDrainFlags(serviceCmd.Flags())
 	undeployCmd.AddCommand(serviceCmd)
 }
 
-func execute(cmd *cobra.Command) {
+func execute(cmd *cobra.Command) error {
+	var err error
+	envName, err = config.EnsureEnvPresent(envName)
+	if err != nil {
+		return err
+	}
 	if envName == "prod" {
 		log.Infof("Undeploying service %s in production environment enter PROD to confirm", name)
 		consentMessage := fmt.Sprintf(constant.ConsentMessageTemplate, "PROD")
-		util.AskForConfirmation("PROD", consentMessage)
+		if err := util.AskForConfirmation("PROD", consentMessage); err != nil {
+			return err
+		}
 	}
-	envName = config.EnsureEnvPresent(envName)
 
 	ctx := cmd.Context()
 	verboseEnabled, err := cmd.Flags().GetBool(constant.VerboseFlag)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	outputFormat, err := output.ProgressFormat(cmd)
+	if err != nil {
+		return err
+	}
+	logDrain, err := service.LogDrainFromFlags(cmd.Flags())
+	if err != nil {
+		return err
 	}
 
 	ctx = context.WithValue(ctx, constant.VerboseEnabledKey, verboseEnabled)
+	ctx = context.WithValue(ctx, constant.OutputFormatKey, outputFormat)
+	ctx = context.WithValue(ctx, constant.LogDrainKey, logDrain)
 
 	err = serviceClient.UndeployService(&ctx, &serviceProto.UndeployServiceRequest{
 		EnvName:     envName,
 		ServiceName: name,
 	})
 
-	if err != nil {
-		util.LogGrpcError(err, "Failed to undeploy service: ")
-	}
+	return exitcode.Wrap(err, "Failed to undeploy service: ")
 }
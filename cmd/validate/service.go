@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/dream-horizon-org/odin/pkg/definition"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var definitionFile string
+var provisioningFile string
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Validate service",
+	Args: func(cmd *cobra.Command, args []string) error {
+		return cobra.NoArgs(cmd, args)
+	},
+	Long: "Validate service definition and provisioning files offline",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execute()
+	},
+}
+
+func init() {
+	serviceCmd.Flags().StringVar(&definitionFile, "file", "", "path to the service definition file in JSON or YAML format")
+	serviceCmd.Flags().StringVar(&provisioningFile, "provisioning", "", "path to the provisioning file in JSON or YAML format")
+	if err := serviceCmd.MarkFlagRequired("file"); err != nil {
+		log.Fatal("Error marking 'file' flag as required:", err)
+	}
+	if err := serviceCmd.MarkFlagRequired("provisioning"); err != nil {
+		log.Fatal("Error marking 'provisioning' flag as required:", err)
+	}
+	validateCmd.AddCommand(serviceCmd)
+}
+
+func execute() error {
+	definitionProto, provisioningProto, err := definition.Load(definitionFile, provisioningFile)
+	if err != nil {
+		return exitcode.New(exitcode.Validation, err)
+	}
+
+	violations := definition.Validate(definitionFile, definitionProto, provisioningFile, provisioningProto)
+	for _, violation := range violations {
+		log.Error(violation.String())
+	}
+	if len(violations) > 0 {
+		return exitcode.Errorf(exitcode.Validation, "Validation failed with %d violation(s)", len(violations))
+	}
+	fmt.Println("\033[32mService definition is valid!\033[0m")
+	return nil
+}
@@ -0,0 +1,16 @@
+package validate
+
+import (
+	"github.com/dream-horizon-org/odin/cmd"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate resources",
+	Long:  `Validate resources locally without calling the backend`,
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	deviceCodeGrantType         = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultDevicePollInterval   = 5 * time.Second
+	slowDownIncrement           = 5 * time.Second
+	defaultDeviceCodeExpiry     = 10 * time.Minute
+	deviceRequestTimeout        = 30 * time.Second
+	maxDeviceResponseBodyLength = 1 << 20
+)
+
+// DeviceCodeProviderConfig holds the IdP endpoints used by the device authorization grant
+type DeviceCodeProviderConfig struct {
+	DeviceAuthURL *url.URL
+	TokenURL      *url.URL
+	ClientID      string
+	Scope         string
+}
+
+// DeviceCodeProvider authenticates using the OAuth 2.0 device authorization grant (RFC 8628).
+// It needs neither a local browser nor a callback listener, so it works over SSH and in CI.
+type DeviceCodeProvider struct {
+	httpClient *http.Client
+	sleep      func(time.Duration)
+	now        func() time.Time
+}
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Authenticate shows a verification URL and user code, then polls the token endpoint until the user approves
+func (p *DeviceCodeProvider) Authenticate(providerData *structpb.Struct) (*structpb.Struct, error) {
+	config, err := parseDeviceProviderData(providerData)
+	if err != nil {
+		return nil, fmt.Errorf("parse provider data: %w", err)
+	}
+
+	deviceCode, err := p.requestDeviceCode(config)
+	if err != nil {
+		return nil, fmt.Errorf("request device code: %w", err)
+	}
+
+	log.Info("\nTo authenticate, visit the following URL on any device:")
+	if deviceCode.VerificationURIComplete != "" {
+		log.Info(deviceCode.VerificationURIComplete)
+	} else {
+		log.Info(deviceCode.VerificationURI)
+	}
+	log.Infof("and enter the code: %s\n", deviceCode.UserCode)
+
+	token, err := p.pollToken(config, deviceCode)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"grant_type":   deviceCodeGrantType,
+		"access_token": token.AccessToken,
+	}
+	if token.IDToken != "" {
+		data["id_token"] = token.IDToken
+	}
+	if token.TokenType != "" {
+		data["token_type"] = token.TokenType
+	}
+	return structpb.NewStruct(data)
+}
+
+func (p *DeviceCodeProvider) client() *http.Client {
+	if p.httpClient != nil {
+		return p.httpClient
+	}
+	return &http.Client{Timeout: deviceRequestTimeout}
+}
+
+func (p *DeviceCodeProvider) wait(d time.Duration) {
+	if p.sleep != nil {
+		p.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+func (p *DeviceCodeProvider) currentTime() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+func (p *DeviceCodeProvider) requestDeviceCode(config *DeviceCodeProviderConfig) (*deviceCodeResponse, error) {
+	form := url.Values{}
+	form.Set("client_id", config.ClientID)
+	form.Set("scope", config.Scope)
+
+	body, statusCode, err := p.postForm(config.DeviceAuthURL, form)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		var errorResponse deviceTokenResponse
+		_ = json.Unmarshal(body, &errorResponse)
+		return nil, fmt.Errorf("unexpected status %d: %s %s", statusCode, errorResponse.Error, errorResponse.ErrorDescription)
+	}
+
+	var response deviceCodeResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if response.DeviceCode == "" || response.UserCode == "" || response.VerificationURI == "" {
+		return nil, fmt.Errorf("device_code, user_code and verification_uri are required in the response")
+	}
+	return &response, nil
+}
+
+func (p *DeviceCodeProvider) pollToken(config *DeviceCodeProviderConfig, deviceCode *deviceCodeResponse) (*deviceTokenResponse, error) {
+	interval := defaultDevicePollInterval
+	if deviceCode.Interval > 0 {
+		interval = time.Duration(deviceCode.Interval) * time.Second
+	}
+	expiry := defaultDeviceCodeExpiry
+	if deviceCode.ExpiresIn > 0 {
+		expiry = time.Duration(deviceCode.ExpiresIn) * time.Second
+	}
+	deadline := p.currentTime().Add(expiry)
+
+	form := url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", deviceCode.DeviceCode)
+	form.Set("client_id", config.ClientID)
+
+	for p.currentTime().Before(deadline) {
+		p.wait(interval)
+
+		body, statusCode, err := p.postForm(config.TokenURL, form)
+		if err != nil {
+			return nil, fmt.Errorf("poll token: %w", err)
+		}
+		var response deviceTokenResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("decode token response: %w", err)
+		}
+
+		if statusCode == http.StatusOK && response.Error == "" {
+			if response.AccessToken == "" {
+				return nil, fmt.Errorf("access_token is missing in the token response")
+			}
+			return &response, nil
+		}
+
+		switch response.Error {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += slowDownIncrement
+			continue
+		case "access_denied":
+			return nil, fmt.Errorf("authentication was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired, please try again")
+		default:
+			return nil, fmt.Errorf("token request failed with status %d: %s %s", statusCode, response.Error, response.ErrorDescription)
+		}
+	}
+	return nil, fmt.Errorf("authentication timed out after %s", expiry)
+}
+
+func (p *DeviceCodeProvider) postForm(endpoint *url.URL, form url.Values) ([]byte, int, error) {
+	request, err := http.NewRequest(http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client().Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func(body io.ReadCloser) {
+		if err := body.Close(); err != nil {
+			log.Errorf("Failed to close response body: %v", err)
+		}
+	}(response.Body)
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxDeviceResponseBodyLength))
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, response.StatusCode, nil
+}
+
+func parseDeviceProviderData(data *structpb.Struct) (*DeviceCodeProviderConfig, error) {
+	if data == nil {
+		return nil, fmt.Errorf("provider data is required")
+	}
+
+	fields := data.GetFields()
+	config := &DeviceCodeProviderConfig{
+		ClientID: getStringField(fields, "client_id", ""),
+		Scope:    getStringField(fields, "scope", "email"),
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("client_id is required")
+	}
+
+	var err error
+	config.DeviceAuthURL, err = parseRequiredURL(fields, "device_authorization_url")
+	if err != nil {
+		return nil, err
+	}
+	config.TokenURL, err = parseRequiredURL(fields, "token_url")
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func parseRequiredURL(fields map[string]*structpb.Value, key string) (*url.URL, error) {
+	value := getStringField(fields, key, "")
+	if value == "" {
+		return nil, fmt.Errorf("%s is required", key)
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return parsed, nil
+}
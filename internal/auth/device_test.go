@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestIdP starts a stand-in IdP that answers the token endpoint with the given responses in order
+func newTestIdP(t *testing.T, tokenResponses []map[string]interface{}) (*httptest.Server, *int32) {
+	var tokenCalls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "test-client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "openid email", r.PostForm.Get("scope"))
+		writeJSON(t, w, http.StatusOK, map[string]interface{}{
+			"device_code":      "device-123",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": "https://idp.example.com/activate",
+			"expires_in":       600,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, deviceCodeGrantType, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device-123", r.PostForm.Get("device_code"))
+		assert.Equal(t, "test-client", r.PostForm.Get("client_id"))
+
+		call := atomic.AddInt32(&tokenCalls, 1)
+		response := tokenResponses[int(call)-1]
+		statusCode := http.StatusOK
+		if _, ok := response["error"]; ok {
+			statusCode = http.StatusBadRequest
+		}
+		writeJSON(t, w, statusCode, response)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &tokenCalls
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+func testProviderData(server *httptest.Server) *structpb.Struct {
+	return mustStruct(map[string]interface{}{
+		"client_id":                "test-client",
+		"scope":                    "openid email",
+		"device_authorization_url": server.URL + "/device",
+		"token_url":                server.URL + "/token",
+	})
+}
+
+func TestDeviceCodeProviderAuthenticate(t *testing.T) {
+	t.Run("polls until the user approves", func(t *testing.T) {
+		server, tokenCalls := newTestIdP(t, []map[string]interface{}{
+			{"error": "authorization_pending"},
+			{"error": "slow_down"},
+			{"access_token": "access-123", "id_token": "id-123", "token_type": "Bearer"},
+		})
+		var waits []time.Duration
+		provider := &DeviceCodeProvider{
+			httpClient: server.Client(),
+			sleep:      func(d time.Duration) { waits = append(waits, d) },
+		}
+
+		authData, err := provider.Authenticate(testProviderData(server))
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(tokenCalls))
+		assert.Equal(t, []time.Duration{time.Second, time.Second, 6 * time.Second}, waits)
+		fields := authData.AsMap()
+		assert.Equal(t, "access-123", fields["access_token"])
+		assert.Equal(t, "id-123", fields["id_token"])
+		assert.Equal(t, deviceCodeGrantType, fields["grant_type"])
+	})
+
+	t.Run("fails when the user denies access", func(t *testing.T) {
+		server, _ := newTestIdP(t, []map[string]interface{}{
+			{"error": "access_denied"},
+		})
+		provider := &DeviceCodeProvider{httpClient: server.Client(), sleep: func(time.Duration) {}}
+
+		_, err := provider.Authenticate(testProviderData(server))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "denied")
+	})
+
+	t.Run("fails when the device code expires", func(t *testing.T) {
+		server, _ := newTestIdP(t, []map[string]interface{}{
+			{"error": "authorization_pending"},
+			{"error": "expired_token"},
+		})
+		provider := &DeviceCodeProvider{httpClient: server.Client(), sleep: func(time.Duration) {}}
+
+		_, err := provider.Authenticate(testProviderData(server))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("times out after expires_in", func(t *testing.T) {
+		server, tokenCalls := newTestIdP(t, []map[string]interface{}{
+			{"error": "authorization_pending"},
+			{"error": "authorization_pending"},
+		})
+		now := time.Unix(0, 0)
+		provider := &DeviceCodeProvider{
+			httpClient: server.Client(),
+			sleep:      func(d time.Duration) { now = now.Add(5 * time.Minute) },
+			now:        func() time.Time { return now },
+		}
+
+		_, err := provider.Authenticate(testProviderData(server))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+		assert.Equal(t, int32(2), atomic.LoadInt32(tokenCalls))
+	})
+}
+
+func TestParseDeviceProviderData(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *structpb.Struct
+		errContains string
+	}{
+		{name: "nil input", input: nil, errContains: "provider data is required"},
+		{
+			name:        "missing client_id",
+			input:       mustStruct(map[string]interface{}{"device_authorization_url": "https://idp/device", "token_url": "https://idp/token"}),
+			errContains: "client_id is required",
+		},
+		{
+			name:        "missing device_authorization_url",
+			input:       mustStruct(map[string]interface{}{"client_id": "c", "token_url": "https://idp/token"}),
+			errContains: "device_authorization_url is required",
+		},
+		{
+			name:        "missing token_url",
+			input:       mustStruct(map[string]interface{}{"client_id": "c", "device_authorization_url": "https://idp/device"}),
+			errContains: "token_url is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDeviceProviderData(tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+
+	config, err := parseDeviceProviderData(mustStruct(map[string]interface{}{
+		"client_id":                "c",
+		"device_authorization_url": "https://idp/device",
+		"token_url":                "https://idp/token",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "email", config.Scope)
+	assert.Equal(t, "https://idp/token", config.TokenURL.String())
+}
+
+func TestGetHeadlessProvider(t *testing.T) {
+	provider, err := GetHeadlessProvider("OIDC")
+	require.NoError(t, err)
+	assert.IsType(t, &DeviceCodeProvider{}, provider)
+
+	provider, err = GetHeadlessProvider("anonymous")
+	require.NoError(t, err)
+	assert.IsType(t, &AnonymousProvider{}, provider)
+}
@@ -5,11 +5,19 @@ import (
 	"strings"
 )
 
+const (
+	// OIDCProviderType is the provider type of the browser based authorization code flow
+	OIDCProviderType = "oidc"
+	// DeviceCodeProviderType is the provider type of the device authorization grant
+	DeviceCodeProviderType = "device_code"
+)
+
 var providers = make(map[string]Provider)
 
 func init() {
 	providers["anonymous"] = &AnonymousProvider{}
-	providers["oidc"] = &OIDCProvider{}
+	providers[OIDCProviderType] = &OIDCProvider{}
+	providers[DeviceCodeProviderType] = &DeviceCodeProvider{}
 }
 
 // GetProvider returns the requested authentication provider.
@@ -20,3 +28,12 @@ func GetProvider(providerType string) (Provider, error) {
 	}
 	return provider, nil
 }
+
+// GetHeadlessProvider returns the requested authentication provider, replacing
+// flows that need a local browser with the device authorization grant.
+func GetHeadlessProvider(providerType string) (Provider, error) {
+	if strings.ToLower(providerType) == OIDCProviderType {
+		return GetProvider(DeviceCodeProviderType)
+	}
+	return GetProvider(providerType)
+}
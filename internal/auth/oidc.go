@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	_ "embed"
 	"encoding/base64"
 	"errors"
@@ -32,6 +33,7 @@ const (
 	readHeaderTimeout          = 5 * time.Second
 	closeAfterWriteDelay       = 200 * time.Millisecond
 	defaultCallbackWaitTimeout = 5 * time.Minute
+	codeChallengeMethodS256    = "S256"
 )
 
 type OIDCProviderConfig struct {
@@ -39,6 +41,7 @@ type OIDCProviderConfig struct {
 	AuthURL  *url.URL
 	ClientID string
 	Scope    string
+	PKCE     bool
 }
 
 type OIDCProvider struct{}
@@ -66,7 +69,16 @@ func (p *OIDCProvider) Authenticate(providerData *structpb.Struct) (*structpb.St
 		return nil, fmt.Errorf("generate state: %w", err)
 	}
 
-	authURL := buildAuthURL(config, redirectURL.String(), state)
+	var codeVerifier, codeChallenge string
+	if config.PKCE {
+		codeVerifier, err = generateCodeVerifier(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate code verifier: %w", err)
+		}
+		codeChallenge = codeChallengeS256(codeVerifier)
+	}
+
+	authURL := buildAuthURL(config, redirectURL.String(), state, codeChallenge)
 
 	if err := openBrowser(authURL); err != nil {
 		log.Warnf("Failed to open browser automatically: %v", err)
@@ -80,10 +92,15 @@ func (p *OIDCProvider) Authenticate(providerData *structpb.Struct) (*structpb.St
 		return nil, err
 	}
 
-	return structpb.NewStruct(map[string]interface{}{
+	authData := map[string]interface{}{
 		"authorization_code": authCode,
 		"redirect_uri":       redirectURL.String(),
-	})
+	}
+	if codeVerifier != "" {
+		// The backend needs the verifier to finish the code exchange
+		authData["code_verifier"] = codeVerifier
+	}
+	return structpb.NewStruct(authData)
 }
 
 func waitForCallback(ln net.Listener, expectedState string) (string, error) {
@@ -188,23 +205,14 @@ func parseProviderData(data *structpb.Struct) (*OIDCProviderConfig, error) {
 
 	fields := data.GetFields()
 
-	getString := func(m map[string]*structpb.Value, key string, defaultValue string) string {
-		if v, ok := m[key]; ok && v != nil {
-			val := v.GetStringValue()
-			if val != "" {
-				return val
-			}
-		}
-		return defaultValue
-	}
-
 	config := &OIDCProviderConfig{
-		Name:     getString(fields, "name", ""),
-		ClientID: getString(fields, "client_id", ""),
-		Scope:    getString(fields, "scope", "email"),
+		Name:     getStringField(fields, "name", ""),
+		ClientID: getStringField(fields, "client_id", ""),
+		Scope:    getStringField(fields, "scope", "email"),
+		PKCE:     supportsPKCE(fields),
 	}
 
-	authURLStr := getString(fields, "authorization_url", "")
+	authURLStr := getStringField(fields, "authorization_url", "")
 	if authURLStr == "" {
 		return nil, fmt.Errorf("authorization_url is required")
 	}
@@ -220,13 +228,33 @@ func parseProviderData(data *structpb.Struct) (*OIDCProviderConfig, error) {
 	return config, nil
 }
 
-func buildAuthURL(config *OIDCProviderConfig, redirectURI, state string) *url.URL {
+// supportsPKCE checks if the provider data advertises PKCE, either with a pkce flag
+// or with S256 in code_challenge_methods_supported
+func supportsPKCE(fields map[string]*structpb.Value) bool {
+	if v, ok := fields["pkce"]; ok && v.GetBoolValue() {
+		return true
+	}
+	if v, ok := fields["code_challenge_methods_supported"]; ok {
+		for _, method := range v.GetListValue().GetValues() {
+			if method.GetStringValue() == codeChallengeMethodS256 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func buildAuthURL(config *OIDCProviderConfig, redirectURI, state, codeChallenge string) *url.URL {
 	params := url.Values{}
 	params.Set("client_id", config.ClientID)
 	params.Set("response_type", "code")
 	params.Set("redirect_uri", redirectURI)
 	params.Set("scope", config.Scope)
 	params.Set("state", state)
+	if codeChallenge != "" {
+		params.Set("code_challenge", codeChallenge)
+		params.Set("code_challenge_method", codeChallengeMethodS256)
+	}
 
 	// Create a shallow copy to avoid mutating the original base URL
 	built := *config.AuthURL
@@ -242,6 +270,21 @@ func generateState(r io.Reader) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
+// generateCodeVerifier generates a PKCE code verifier of 43 characters from the unreserved URL-safe alphabet
+func generateCodeVerifier(r io.Reader) (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallengeS256 derives the PKCE code challenge from the verifier as BASE64URL(SHA256(verifier))
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
 func openBrowser(u *url.URL) error {
 	var cmd *exec.Cmd
 	urlStr := u.String()
@@ -115,6 +115,31 @@ func TestParseProviderData(t *testing.T) {
 				require.NotNil(t, config.AuthURL)
 				assert.Equal(t, "https://example.com/auth", config.AuthURL.String())
 				assert.Equal(t, "email", config.Scope)
+				assert.False(t, config.PKCE)
+			},
+		},
+		{
+			name: "pkce flag enables PKCE",
+			input: mustStruct(map[string]interface{}{
+				"authorization_url": "https://example.com/auth",
+				"client_id":         "test-client",
+				"pkce":              true,
+			}),
+			wantErr: false,
+			validate: func(t *testing.T, config *OIDCProviderConfig) {
+				assert.True(t, config.PKCE)
+			},
+		},
+		{
+			name: "S256 challenge method enables PKCE",
+			input: mustStruct(map[string]interface{}{
+				"authorization_url":                "https://example.com/auth",
+				"client_id":                        "test-client",
+				"code_challenge_methods_supported": []interface{}{"plain", "S256"},
+			}),
+			wantErr: false,
+			validate: func(t *testing.T, config *OIDCProviderConfig) {
+				assert.True(t, config.PKCE)
 			},
 		},
 		{
@@ -178,7 +203,7 @@ func TestBuildAuthURL(t *testing.T) {
 	redirectURI := "http://localhost:8080/callback"
 	state := "test-state-123"
 
-	authURL := buildAuthURL(config, redirectURI, state)
+	authURL := buildAuthURL(config, redirectURI, state, "")
 
 	assert.Equal(t, "https://example.com/oauth/authorize", config.AuthURL.String(), "buildAuthURL() should not mutate original config.AuthURL")
 
@@ -192,6 +217,36 @@ func TestBuildAuthURL(t *testing.T) {
 	assert.Equal(t, redirectURI, query.Get("redirect_uri"))
 	assert.Equal(t, "openid profile email", query.Get("scope"))
 	assert.Equal(t, state, query.Get("state"))
+	assert.False(t, query.Has("code_challenge"), "buildAuthURL() should not add PKCE params without a challenge")
+	assert.False(t, query.Has("code_challenge_method"))
+
+	authURL = buildAuthURL(config, redirectURI, state, "test-challenge")
+	query = authURL.Query()
+	assert.Equal(t, "test-challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	// Test vector from RFC 7636 Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", codeChallengeS256(verifier))
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	r := rand.New(rand.NewSource(12345))
+
+	verifier, err := generateCodeVerifier(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, 43, len(verifier), "code verifier must be between 43 and 128 characters")
+	for _, char := range verifier {
+		assert.True(t, unicode.IsLetter(char) || unicode.IsDigit(char) || char == '-' || char == '_',
+			"generateCodeVerifier() contains invalid character: %c", char)
+	}
+
+	verifier2, err := generateCodeVerifier(r)
+	require.NoError(t, err)
+	assert.NotEqual(t, verifier, verifier2)
 }
 
 func TestSendSuccessPage(t *testing.T) {
@@ -6,3 +6,14 @@ import "google.golang.org/protobuf/types/known/structpb"
 type Provider interface {
 	Authenticate(providerData *structpb.Struct) (*structpb.Struct, error)
 }
+
+// getStringField returns the string value of the key in the provider data or the default value when empty
+func getStringField(fields map[string]*structpb.Value, key string, defaultValue string) string {
+	if v, ok := fields[key]; ok && v != nil {
+		val := v.GetStringValue()
+		if val != "" {
+			return val
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenClaims decodes the claims of a JWT access token without verifying its signature
+func TokenClaims(token string) (map[string]interface{}, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode token payload: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parse token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// TokenExpiry returns the expiry of a JWT access token when it can be read
+func TokenExpiry(token string) (time.Time, bool) {
+	claims, err := TokenClaims(token)
+	if err != nil {
+		return time.Time{}, false
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
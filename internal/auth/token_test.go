@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	encode := func(payload string) string {
+		return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+	}
+
+	expiry, ok := TokenExpiry(encode(`{"sub":"user","exp":1700000000}`))
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1700000000, 0), expiry)
+
+	expiry, ok = TokenExpiry("Bearer " + encode(`{"exp":1700000000}`))
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1700000000, 0), expiry)
+
+	_, ok = TokenExpiry(encode(`{"sub":"user"}`))
+	assert.False(t, ok, "token without exp claim")
+
+	_, ok = TokenExpiry("opaque-token")
+	assert.False(t, ok, "opaque token")
+}
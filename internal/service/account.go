@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	providerAccount "github.com/dream-horizon-org/odin/proto/gen/go/dream11/oam/provideraccount/v1"
+)
+
+// ProviderAccount performs operation on cloud provider accounts like list, describe
+type ProviderAccount struct{}
+
+// GetProviderAccount Get provider account by name
+func (p *ProviderAccount) GetProviderAccount(ctx *context.Context, request *providerAccount.GetProviderAccountRequest) (*providerAccount.GetProviderAccountResponse, error) {
+	conn, requestCtx, err := grpcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := providerAccount.NewProviderAccountServiceClient(conn)
+	response, err := client.GetProviderAccount(*requestCtx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetProviderAccounts Get provider accounts by names, all accounts are returned when no name is given
+func (p *ProviderAccount) GetProviderAccounts(ctx *context.Context, request *providerAccount.GetProviderAccountsRequest) (*providerAccount.GetProviderAccountsResponse, error) {
+	conn, requestCtx, err := grpcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := providerAccount.NewProviderAccountServiceClient(conn)
+	response, err := client.GetProviderAccounts(*requestCtx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -2,53 +2,119 @@ package service
 
 import (
 	"context"
-	"crypto/tls"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/app"
 	"github.com/dream-horizon-org/odin/pkg/config"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/proxy"
+	"github.com/dream-horizon-org/odin/pkg/tlsconfig"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
-	"google.golang.org/grpc/metadata"
 )
 
-func getTLSOpts(appConfig *configuration.Configuration) grpc.DialOption {
-	tlsConf := tls.Config{
-		ServerName: strings.Split(appConfig.BackendAddress, ":")[0],
-	}
+var insecureWarningOnce sync.Once
+
+// getTLSOpts returns the transport credentials of the profile: plaintext, or TLS with its CA bundle, client
+// certificate, server name and minimum version
+func getTLSOpts(appConfig *configuration.Configuration) (grpc.DialOption, error) {
 	if appConfig.Plaintext {
 		// Disable TLS
-		return grpc.WithTransportCredentials(insecure.NewCredentials())
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+	tlsConf, err := tlsconfig.New(appConfig)
+	if err != nil {
+		return nil, err
 	}
-	if appConfig.Insecure {
+	if tlsconfig.SkipsVerification(appConfig) {
 		// Perform TLS handshake but skip certificate verification
-		tlsConf.InsecureSkipVerify = true
+		insecureWarningOnce.Do(func() { log.Warn(tlsconfig.InsecureWarning(appConfig)) })
 	}
-
-	return grpc.WithTransportCredentials(credentials.NewTLS(&tlsConf))
-
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)), nil
 }
 
+// grpcClient returns the connection to the backend shared by every service of the process, and the request context
+// carrying the trace id of the call. The connection must not be closed by the caller.
 func grpcClient(ctx *context.Context) (*grpc.ClientConn, *context.Context, error) {
-	appConfig := config.GetConfig()
-	var traceID string
-	var contextWithTrace context.Context
-	if (*ctx).Value(constant.TraceIDKey) == nil {
-		traceID = util.GenerateTraceID()
-		contextWithTrace = context.WithValue(*ctx, constant.TraceIDKey, traceID)
-	} else {
-		traceID = (*ctx).Value(constant.TraceIDKey).(string)
-		contextWithTrace = *ctx
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	requestCtx := *ctx
+	if requestCtx.Value(constant.TraceIDKey) == nil {
+		requestCtx = context.WithValue(requestCtx, constant.TraceIDKey, util.GenerateTraceID())
 	}
 
 	if appConfig.BackendAddress == "" {
-		log.Fatal("Cannot create grpc client: Backend address is empty in config! Run `odin configure` to set backend address")
+		return nil, nil, exitcode.Errorf(exitcode.Validation, "Cannot create grpc client: Backend address is empty in config! Run `odin configure` or set ODIN_BACKEND_ADDRESS to set backend address")
+	}
+	warnIfTokenExpiring(appConfig.AccessToken)
+	timeout, err := config.RequestTimeout(appConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	conn, err := backend.get(requestCtx, appConfig, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, &requestCtx, nil
+}
+
+// backend is the connection shared by every service of the process
+var backend = &connection{}
+
+// connection holds a single connection to the backend, dialed on first use and dialed again only when the backend
+// address, TLS or proxy settings change
+type connection struct {
+	mutex       sync.Mutex
+	conn        *grpc.ClientConn
+	settings    configuration.Configuration
+	accessToken string
+	timeout     time.Duration
+}
+
+// get returns the connection for the configuration, and keeps its access token and request timeout for the next calls
+func (c *connection) get(ctx context.Context, appConfig *configuration.Configuration, timeout time.Duration) (*grpc.ClientConn, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.accessToken = appConfig.AccessToken
+	c.timeout = timeout
+
+	settings := configuration.Configuration{
+		BackendAddress: appConfig.BackendAddress,
+		Insecure:       appConfig.Insecure,
+		Plaintext:      appConfig.Plaintext,
+		CACert:         appConfig.CACert,
+		ClientCert:     appConfig.ClientCert,
+		ClientKey:      appConfig.ClientKey,
+		TLSServerName:  appConfig.TLSServerName,
+		MinTLSVersion:  appConfig.MinTLSVersion,
+		ProxyURL:       appConfig.ProxyURL,
+	}
+	if c.conn != nil && c.settings == settings {
+		return c.conn, nil
+	}
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil {
+			log.Debugf("Error closing connection: %v", err)
+		}
+		c.conn = nil
+	}
+
+	transportCredentials, err := getTLSOpts(appConfig)
+	if err != nil {
+		return nil, err
+	}
+	target, dial, err := dialTarget(ctx, appConfig)
+	if err != nil {
+		return nil, err
 	}
 	opts := []grpc.DialOption{
 		grpc.WithKeepaliveParams(
@@ -57,14 +123,81 @@ func grpcClient(ctx *context.Context) (*grpc.ClientConn, *context.Context, error
 				Timeout:             20 * time.Second,
 				PermitWithoutStream: true,
 			}),
-		getTLSOpts(appConfig),
+		transportCredentials,
+		grpc.WithContextDialer(dial),
+		grpc.WithUserAgent(app.App.Name + "-cli/" + app.App.Version),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptors()...),
+		grpc.WithChainStreamInterceptor(c.metadataStreamInterceptor, reauthStreamInterceptor, loggingStreamInterceptor),
 	}
-	conn, err := grpc.NewClient(appConfig.BackendAddress, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	c.settings = settings
+	return conn, nil
+}
 
+// unaryInterceptors returns the interceptors of unary calls. The deadline applies to each attempt of reauth, so that
+// the call made with the refreshed token isn't bound by the time spent re-authenticating.
+func (c *connection) unaryInterceptors() []grpc.UnaryClientInterceptor {
+	return []grpc.UnaryClientInterceptor{c.metadataUnaryInterceptor, reauthUnaryInterceptor, c.deadlineUnaryInterceptor, loggingUnaryInterceptor}
+}
+
+// dialTarget returns the target of the connection and the function dialing it, directly or through the proxy of the
+// profile or HTTPS_PROXY
+func dialTarget(ctx context.Context, appConfig *configuration.Configuration) (string, proxy.DialFunc, error) {
+	proxyURL, err := proxy.Resolve(appConfig.ProxyURL, appConfig.BackendAddress)
 	if err != nil {
-		return nil, nil, err
+		return "", nil, err
+	}
+	dial, err := proxy.Dialer(proxyURL)
+	if err != nil {
+		return "", nil, err
+	}
+	logf := callLogger(ctx)
+	if proxyURL == nil {
+		if logf != nil {
+			logf("Connecting to %s directly", appConfig.BackendAddress)
+		}
+		return appConfig.BackendAddress, dial, nil
+	}
+	if logf != nil {
+		logf("Connecting to %s through proxy %s", appConfig.BackendAddress, proxyURL.Redacted())
+	}
+	// The proxy resolves the backend address, which may not resolve locally
+	return "passthrough:///" + appConfig.BackendAddress, dial, nil
+}
+
+// token returns the access token of the last call
+func (c *connection) token() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.accessToken
+}
+
+// requestTimeout returns the request timeout of the last call, 0 for none
+func (c *connection) requestTimeout() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.timeout
+}
+
+// close closes the connection, if any
+func (c *connection) close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// CloseConnection closes the connection to the backend, once the command is done
+func CloseConnection() {
+	if err := backend.close(); err != nil {
+		log.Debugf("Error closing connection: %v", err)
 	}
-	// Enrich context with authorisation metadata
-	requestCtx := metadata.AppendToOutgoingContext(contextWithTrace, "Authorization", appConfig.AccessToken, string(constant.TraceIDKey), traceID)
-	return conn, &requestCtx, nil
 }
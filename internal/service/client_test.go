@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	auth "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/auth/v1"
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestConnectionReuse(t *testing.T) {
+	c := &connection{}
+	defer func() { assert.NoError(t, c.close()) }()
+
+	first, err := c.get(context.Background(), &configuration.Configuration{BackendAddress: "localhost:443", AccessToken: "first"}, constant.RequestTimeout)
+	require.NoError(t, err)
+	second, err := c.get(context.Background(), &configuration.Configuration{BackendAddress: "localhost:443", AccessToken: "second"}, constant.RequestTimeout)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, "second", c.token())
+
+	plaintext, err := c.get(context.Background(), &configuration.Configuration{BackendAddress: "localhost:443", Plaintext: true}, constant.RequestTimeout)
+	require.NoError(t, err)
+	assert.NotSame(t, first, plaintext)
+	other, err := c.get(context.Background(), &configuration.Configuration{BackendAddress: "localhost:8443", Plaintext: true}, constant.RequestTimeout)
+	require.NoError(t, err)
+	assert.NotSame(t, plaintext, other)
+}
+
+func TestMetadataInterceptor(t *testing.T) {
+	c := &connection{accessToken: "token"}
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		traceID string
+	}{
+		{name: "trace id of the context", ctx: context.WithValue(context.Background(), constant.TraceIDKey, "trace"), traceID: "trace"},
+		{name: "generated trace id", ctx: context.Background()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			require.NoError(t, c.metadataUnaryInterceptor(tt.ctx, "/test", nil, nil, nil, invoker))
+			assert.Equal(t, []string{"token"}, md.Get(authorizationKey))
+			require.Len(t, md.Get(string(constant.TraceIDKey)), 1)
+			if tt.traceID != "" {
+				assert.Equal(t, tt.traceID, md.Get(string(constant.TraceIDKey))[0])
+			} else {
+				assert.NotEmpty(t, md.Get(string(constant.TraceIDKey))[0])
+			}
+		})
+	}
+}
+
+func TestDeadlineInterceptor(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	}
+
+	c := &connection{timeout: time.Minute}
+	require.NoError(t, c.deadlineUnaryInterceptor(context.Background(), "/test", nil, nil, nil, invoker))
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+	require.NoError(t, c.deadlineUnaryInterceptor(ctx, "/test", nil, nil, nil, invoker))
+	assert.Equal(t, expected, deadline)
+
+	// A timeout of 0 sets no deadline
+	c = &connection{}
+	require.NoError(t, c.deadlineUnaryInterceptor(context.Background(), "/test", nil, nil, nil, invoker))
+	assert.False(t, hasDeadline)
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return errors.New("unavailable")
+	}
+	request := &environment.DescribeEnvironmentRequest{EnvName: "dev"}
+
+	require.Error(t, loggingUnaryInterceptor(context.Background(), "/test", request, nil, nil, invoker))
+	assert.Empty(t, hook.AllEntries())
+
+	verbose := context.WithValue(context.Background(), constant.VerboseEnabledKey, true)
+	require.Error(t, loggingUnaryInterceptor(verbose, "/test", request, nil, nil, invoker))
+	entries := hook.AllEntries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, log.InfoLevel, entries[0].Level)
+	assert.Equal(t, `--> /test {"envName":"dev"}`, entries[0].Message)
+	assert.Contains(t, entries[1].Message, "<-- /test failed after")
+}
+
+func TestLoggingInterceptorRedactsAuthService(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	method := authServicePrefix + "GetUserToken"
+	data, err := structpb.NewStruct(map[string]interface{}{"id_token": "provider-secret"})
+	require.NoError(t, err)
+	request := &auth.GetUserTokenRequest{Data: data}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		reply.(*auth.GetUserTokenResponse).Token = "access-secret"
+		return nil
+	}
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{}, nil
+	}
+
+	verbose := context.WithValue(context.Background(), constant.VerboseEnabledKey, true)
+	require.NoError(t, loggingUnaryInterceptor(verbose, method, request, &auth.GetUserTokenResponse{}, nil, invoker))
+	stream, err := loggingStreamInterceptor(verbose, &grpc.StreamDesc{ServerStreams: true}, nil, method, streamer)
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(request))
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Message, "provider-secret")
+		assert.NotContains(t, entry.Message, "access-secret")
+		assert.Contains(t, entry.Message, redactedMessage)
+	}
+}
+
+// chainUnary calls the interceptors in order, as the connection does
+func chainUnary(interceptors []grpc.UnaryClientInterceptor, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	return invoker
+}
+
+func TestReauthenticationOutsideDeadline(t *testing.T) {
+	stubReauth(t, "refreshed", true)
+	var attempts []context.Context
+	// The re-authentication outlasts the deadline of the failed attempt
+	authenticate = func(ctx context.Context) (string, error) {
+		<-attempts[0].Done()
+		return "refreshed", nil
+	}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts = append(attempts, ctx)
+		if outgoingToken(ctx) == "expired" {
+			return errUnauthenticated
+		}
+		return ctx.Err()
+	}
+
+	c := &connection{accessToken: "expired", timeout: 50 * time.Millisecond}
+	require.NoError(t, chainUnary(c.unaryInterceptors(), invoker)(context.Background(), "/test", nil, nil, nil))
+	require.Len(t, attempts, 2)
+	_, hasDeadline := attempts[1].Deadline()
+	assert.True(t, hasDeadline)
+}
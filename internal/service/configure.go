@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	authProvider "github.com/dream-horizon-org/odin/internal/auth"
 	"github.com/dream-horizon-org/odin/pkg/constant"
 	auth "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/auth/v1"
 	log "github.com/sirupsen/logrus"
@@ -42,3 +44,37 @@ func (c *Configure) GetUserToken(ctx *context.Context, request *auth.GetUserToke
 
 	return response, nil
 }
+
+// Authenticate runs the authentication flow of the organisation's auth provider and returns a new user token.
+// When noBrowser is set, flows that need a local browser are replaced by the device authorization grant.
+func (c *Configure) Authenticate(ctx *context.Context, orgID int64, noBrowser bool) (string, error) {
+	authProviderResponse, err := c.GetAuthProvider(ctx, &auth.GetAuthProviderRequest{
+		OrgId: &orgID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth provider: %w", err)
+	}
+
+	getProvider := authProvider.GetProvider
+	if noBrowser {
+		getProvider = authProvider.GetHeadlessProvider
+	}
+	provider, err := getProvider(authProviderResponse.Type)
+	if err != nil {
+		return "", fmt.Errorf("error getting auth provider: %w", err)
+	}
+
+	authData, err := provider.Authenticate(authProviderResponse.Data)
+	if err != nil {
+		return "", fmt.Errorf("error authenticating: %w", err)
+	}
+
+	tokenResponse, err := c.GetUserToken(ctx, &auth.GetUserTokenRequest{
+		OrgId: &orgID,
+		Data:  authData,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+	return tokenResponse.Token, nil
+}
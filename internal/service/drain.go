@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/retryable"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+const (
+	logDrainTimeoutFlag     = "log-drain-timeout"
+	logDrainQuietPeriodFlag = "log-drain-quiet-period"
+)
+
+// LogDrain controls how long the live logs are read once an action completes: until no log is received for the
+// quiet period, the backend ends the logs or the timeout is reached
+type LogDrain struct {
+	QuietPeriod time.Duration
+	Timeout     time.Duration
+}
+
+// DefaultLogDrain is used when the command sets no log drain
+var DefaultLogDrain = LogDrain{QuietPeriod: 5 * time.Second, Timeout: 30 * time.Second}
+
+// AddLogDrainFlags adds the flags controlling the log drain to the commands streaming the logs of an action
+func AddLogDrainFlags(flags *pflag.FlagSet) {
+	flags.Duration(logDrainTimeoutFlag, DefaultLogDrain.Timeout, "maximum time to wait for the remaining logs once the action completes")
+	flags.Duration(logDrainQuietPeriodFlag, DefaultLogDrain.QuietPeriod, "stop waiting for the remaining logs once none is received for this long")
+}
+
+// LogDrainFromFlags reads the log drain set by the flags
+func LogDrainFromFlags(flags *pflag.FlagSet) (LogDrain, error) {
+	timeout, err := flags.GetDuration(logDrainTimeoutFlag)
+	if err != nil {
+		return LogDrain{}, err
+	}
+	quietPeriod, err := flags.GetDuration(logDrainQuietPeriodFlag)
+	if err != nil {
+		return LogDrain{}, err
+	}
+	if timeout < 0 || quietPeriod < 0 {
+		return LogDrain{}, exitcode.Errorf(exitcode.Validation, "--%s and --%s must not be negative", logDrainTimeoutFlag, logDrainQuietPeriodFlag)
+	}
+	return LogDrain{QuietPeriod: quietPeriod, Timeout: timeout}, nil
+}
+
+// logDrain tracks the live logs of an action, so that the logs still in flight when it completes are read before
+// the log stream is cancelled
+type logDrain struct {
+	LogDrain
+	clock    retryable.Clock
+	received chan struct{}
+	ended    chan struct{}
+	endOnce  sync.Once
+	draining atomic.Bool
+}
+
+func newLogDrain(ctx *context.Context) *logDrain {
+	timings, ok := (*ctx).Value(constant.LogDrainKey).(LogDrain)
+	if !ok {
+		timings = DefaultLogDrain
+	}
+	return &logDrain{
+		LogDrain: timings,
+		clock:    retryable.SystemClock,
+		received: make(chan struct{}, 1),
+		ended:    make(chan struct{}),
+	}
+}
+
+// receive records that a log was received
+func (d *logDrain) receive() {
+	select {
+	case d.received <- struct{}{}:
+	default:
+	}
+}
+
+// end records that the backend sent all the logs of the action
+func (d *logDrain) end() {
+	d.endOnce.Do(func() { close(d.ended) })
+}
+
+// completed tells whether the action completed, after which the end of the log stream is the end of the logs
+func (d *logDrain) completed() bool {
+	return d.draining.Load()
+}
+
+// wait blocks until no log is received for the quiet period, the logs end or the timeout is reached
+func (d *logDrain) wait() {
+	d.draining.Store(true)
+	timeout := d.clock.After(d.Timeout)
+	quiet := d.clock.After(d.QuietPeriod)
+
+	for {
+		select {
+		case <-d.ended:
+			return
+		case <-quiet:
+			return
+		case <-timeout:
+			log.Debugf("Stopped waiting for logs after %s", d.Timeout)
+			return
+		case <-d.received:
+			quiet = d.clock.After(d.QuietPeriod)
+		}
+	}
+}
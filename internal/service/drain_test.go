@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/retryable"
+	logs "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
+	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStream returns the responses in order, then io.EOF
+type fakeStream struct {
+	responses []*serviceProto.DeployServiceResponse
+}
+
+func (s *fakeStream) Recv() (*serviceProto.DeployServiceResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, nil
+}
+
+// fakeTimer is a timer of the manual clock, fired by the test
+type fakeTimer struct {
+	duration time.Duration
+	c        chan time.Time
+}
+
+func (t *fakeTimer) fire() {
+	t.c <- time.Time{}
+}
+
+// manualClock hands every timer to the test, which decides when it fires
+type manualClock struct {
+	timers chan *fakeTimer
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{timers: make(chan *fakeTimer, 16)}
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	timer := &fakeTimer{duration: d, c: make(chan time.Time, 1)}
+	c.timers <- timer
+	return timer.c
+}
+
+// next returns the next timer started on the clock
+func (c *manualClock) next(t *testing.T) *fakeTimer {
+	select {
+	case timer := <-c.timers:
+		return timer
+	case <-time.After(5 * time.Second):
+		t.Fatal("no timer started")
+		return nil
+	}
+}
+
+// immediateClock fires every timer at once
+type immediateClock struct{}
+
+func (immediateClock) After(time.Duration) <-chan time.Time {
+	c := make(chan time.Time, 1)
+	c <- time.Time{}
+	return c
+}
+
+func testDrain(clock retryable.Clock) *logDrain {
+	ctx := context.WithValue(context.Background(), constant.LogDrainKey, LogDrain{QuietPeriod: time.Second, Timeout: time.Minute})
+	drain := newLogDrain(&ctx)
+	drain.clock = clock
+	return drain
+}
+
+// startWait waits for the logs in the background, returning a channel closed once done along with the timeout and
+// quiet period timers
+func startWait(t *testing.T, drain *logDrain, clock *manualClock) (<-chan struct{}, *fakeTimer, *fakeTimer) {
+	done := make(chan struct{})
+	go func() {
+		drain.wait()
+		close(done)
+	}()
+	timeout, quiet := clock.next(t), clock.next(t)
+	assert.Equal(t, time.Minute, timeout.duration)
+	assert.Equal(t, time.Second, quiet.duration)
+	return done, timeout, quiet
+}
+
+// assertDone checks whether the drain finished waiting
+func assertDone(t *testing.T, done <-chan struct{}, expected bool) {
+	if expected {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("still waiting for logs")
+		}
+		return
+	}
+	select {
+	case <-done:
+		t.Fatal("stopped waiting for logs")
+	default:
+	}
+}
+
+func TestLogDrainQuietPeriod(t *testing.T) {
+	clock := newManualClock()
+	drain := testDrain(clock)
+	done, _, quiet := startWait(t, drain, clock)
+	assert.True(t, drain.completed())
+
+	quiet.fire()
+	assertDone(t, done, true)
+}
+
+func TestLogDrainWaitsWhileLogsArrive(t *testing.T) {
+	clock := newManualClock()
+	drain := testDrain(clock)
+	done, _, quiet := startWait(t, drain, clock)
+
+	drain.receive()
+	restarted := clock.next(t)
+	// The quiet period before the log is over
+	quiet.fire()
+	assertDone(t, done, false)
+
+	restarted.fire()
+	assertDone(t, done, true)
+}
+
+func TestLogDrainTimeout(t *testing.T) {
+	clock := newManualClock()
+	drain := testDrain(clock)
+	done, timeout, _ := startWait(t, drain, clock)
+
+	drain.receive()
+	clock.next(t)
+	timeout.fire()
+	assertDone(t, done, true)
+}
+
+func TestLogDrainEnd(t *testing.T) {
+	clock := newManualClock()
+	drain := testDrain(clock)
+	done, _, _ := startWait(t, drain, clock)
+
+	drain.end()
+	assertDone(t, done, true)
+}
+
+// fakeLogs follows the logs until released, then delivers a last log and closes the stream
+type fakeLogs struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (l *fakeLogs) GetLogs(ctx *context.Context, request *logs.GetLogsRequest, options LogOptions) ([]int64, error) {
+	l.calls.Add(1)
+	<-l.release
+	options.Received()
+	return []int64{1}, nil
+}
+
+func TestStreamLogsEndsDrain(t *testing.T) {
+	fake := &fakeLogs{release: make(chan struct{})}
+	previous := logsClient
+	logsClient = fake
+	t.Cleanup(func() { logsClient = previous })
+
+	clock := newManualClock()
+	drain := testDrain(clock)
+	ctx := context.WithValue(context.Background(), constant.TraceIDKey, "trace")
+	streamed := make(chan struct{})
+	go func() {
+		streamLogs(context.Background(), &ctx, newProgressWriter(io.Discard, true, "trace", time.Now), drain, "service")
+		close(streamed)
+	}()
+
+	// The action completes while the logs are followed, then the backend closes the logs
+	done, _, _ := startWait(t, drain, clock)
+	close(fake.release)
+	assertDone(t, streamed, true)
+	assertDone(t, done, true)
+	assert.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestHandleResponseDrainsLogs(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		code   int
+	}{
+		{name: "successful", status: "SUCCESSFUL", code: exitcode.Success},
+		{name: "failed", status: "FAILED", code: exitcode.DeploymentFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &fakeStream{responses: []*serviceProto.DeployServiceResponse{
+				{ServiceResponse: serviceResponse("IN_PROGRESS")},
+				{ServiceResponse: serviceResponse(tt.status)},
+			}}
+			drain := testDrain(immediateClock{})
+			cancelled := false
+			cancel := func() {
+				assert.True(t, drain.completed(), "cancelled before the logs were drained")
+				cancelled = true
+			}
+
+			err := handleResponse(stream, cancel, newProgressWriter(io.Discard, true, "trace", time.Now), drain, (*serviceProto.DeployServiceResponse).GetServiceResponse)
+			assert.Equal(t, tt.code, exitcode.FromError(err))
+			assert.True(t, cancelled)
+		})
+	}
+}
+
+func TestLogDrainFromFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddLogDrainFlags(flags)
+	drain, err := LogDrainFromFlags(flags)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLogDrain, drain)
+
+	require.NoError(t, flags.Parse([]string{"--log-drain-timeout=1m", "--log-drain-quiet-period=2s"}))
+	drain, err = LogDrainFromFlags(flags)
+	require.NoError(t, err)
+	assert.Equal(t, LogDrain{QuietPeriod: 2 * time.Second, Timeout: time.Minute}, drain)
+
+	require.NoError(t, flags.Parse([]string{"--log-drain-timeout=-1s"}))
+	_, err = LogDrainFromFlags(flags)
+	assert.Equal(t, exitcode.Validation, exitcode.FromError(err))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/briandowns/spinner"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/retryable"
 	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
 	log "github.com/sirupsen/logrus"
 )
@@ -15,14 +16,21 @@ import (
 // Environment performs operation on environment like create, list, describe, delete
 type Environment struct{}
 
+// StatusWriter handles a single status of an environment
+type StatusWriter func(response *environment.StatusEnvironmentResponse)
+
 // ListEnvironments List environments
 func (e *Environment) ListEnvironments(ctx *context.Context, request *environment.ListEnvironmentRequest) (*environment.ListEnvironmentResponse, error) {
-	conn, requestCtx, err := grpcClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-	client := environment.NewEnvironmentServiceClient(conn)
-	response, err := client.ListEnvironment(*requestCtx, request)
+	var response *environment.ListEnvironmentResponse
+	err := withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
+		client := environment.NewEnvironmentServiceClient(conn)
+		response, err = client.ListEnvironment(*requestCtx, request)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -32,62 +40,52 @@ func (e *Environment) ListEnvironments(ctx *context.Context, request *environmen
 
 // CreateEnvironment creates environment
 func (e *Environment) CreateEnvironment(ctx *context.Context, request *environment.CreateEnvironmentRequest) error {
-	conn, requestCtx, err := grpcClient(ctx)
-	if err != nil {
-		return err
-	}
-	client := environment.NewEnvironmentServiceClient(conn)
-	stream, err := client.CreateEnvironment(*requestCtx, request)
-	if err != nil {
-		return err
-	}
-
 	log.Info("\nCreating environment...\n")
-	spinnerInstance := spinner.New(spinner.CharSets[constant.SpinnerType], constant.SpinnerDelay)
-	err = spinnerInstance.Color(constant.SpinnerColor, constant.SpinnerStyle)
-	if err != nil {
-		return err
-	}
-	var message string
-	for {
-		response, err := stream.Recv()
-		spinnerInstance.Stop()
+	return withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
 		if err != nil {
-			if errors.Is(err, context.Canceled) || err == io.EOF {
-				break
-			}
 			return err
 		}
-		if response != nil {
-			message = response.Message
-			spinnerInstance.Prefix = fmt.Sprintf(" %s  ", response.Message)
-			spinnerInstance.Start()
+		client := environment.NewEnvironmentServiceClient(conn)
+		stream, err := client.CreateEnvironment(*requestCtx, request)
+		if err != nil {
+			return err
 		}
-	}
-	log.Info(message)
-	return err
+		return followEnvironmentAction[*environment.CreateEnvironmentResponse](stream)
+	})
 }
 
 // DeleteEnvironment deletes environment
 func (e *Environment) DeleteEnvironment(ctx *context.Context, request *environment.DeleteEnvironmentRequest) error {
-	conn, requestCtx, err := grpcClient(ctx)
-	if err != nil {
-		return err
-	}
+	log.Info("\nDeleting environment...\n")
+	return withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
 
-	client := environment.NewEnvironmentServiceClient(conn)
-	stream, err := client.DeleteEnvironment(*requestCtx, request)
+		client := environment.NewEnvironmentServiceClient(conn)
+		stream, err := client.DeleteEnvironment(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		return followEnvironmentAction[*environment.DeleteEnvironmentResponse](stream)
+	})
+}
 
-	if err != nil {
-		return err
-	}
+// environmentActionResponse is a message of an environment being created or deleted
+type environmentActionResponse interface {
+	GetMessage() string
+}
 
-	log.Info("\nDeleting environment...\n")
+// followEnvironmentAction shows the messages of an environment being created or deleted until the stream ends. Errors
+// received once the backend answered are not retried, as the action already started.
+func followEnvironmentAction[R environmentActionResponse](stream StreamReceiverInterface[R]) error {
 	spinnerInstance := spinner.New(spinner.CharSets[constant.SpinnerType], constant.SpinnerDelay)
-	err = spinnerInstance.Color(constant.SpinnerColor, constant.SpinnerStyle)
-	if err != nil {
+	if err := spinnerInstance.Color(constant.SpinnerColor, constant.SpinnerStyle); err != nil {
 		return err
 	}
+	started := false
 	var message string
 	for {
 		response, err := stream.Recv()
@@ -96,28 +94,33 @@ func (e *Environment) DeleteEnvironment(ctx *context.Context, request *environme
 			if errors.Is(err, context.Canceled) || err == io.EOF {
 				break
 			}
+			if started {
+				return retryable.NewRetryableError(err, false)
+			}
 			return err
 		}
-		if response != nil {
-			message = response.Message
-			spinnerInstance.Prefix = fmt.Sprintf(" %s  ", response.Message)
-			spinnerInstance.Start()
-		}
+		started = true
+		message = response.GetMessage()
+		spinnerInstance.Prefix = fmt.Sprintf(" %s  ", message)
+		spinnerInstance.Start()
 	}
 	log.Info(message)
-	return err
+	return nil
 }
 
 // DescribeEnvironment shows environment details including services and resources in it
 func (e *Environment) DescribeEnvironment(ctx *context.Context, request *environment.DescribeEnvironmentRequest) (*environment.DescribeEnvironmentResponse, error) {
-	conn, requestCtx, err := grpcClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	client := environment.NewEnvironmentServiceClient(conn)
-	response, err := client.DescribeEnvironment(*requestCtx, request)
+	var response *environment.DescribeEnvironmentResponse
+	err := withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
 
+		client := environment.NewEnvironmentServiceClient(conn)
+		response, err = client.DescribeEnvironment(*requestCtx, request)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,33 +130,67 @@ func (e *Environment) DescribeEnvironment(ctx *context.Context, request *environ
 
 // EnvironmentStatus shows environment status including services and components in it
 func (e *Environment) EnvironmentStatus(ctx *context.Context, request *environment.StatusEnvironmentRequest) (*environment.StatusEnvironmentResponse, error) {
-	conn, requestCtx, err := grpcClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	client := environment.NewEnvironmentServiceClient(conn)
 	log.Info("Getting environment status...")
 	spinnerInstance := spinner.New(spinner.CharSets[constant.SpinnerType], constant.SpinnerDelay)
 	_ = spinnerInstance.Color(constant.SpinnerColor, constant.SpinnerStyle)
 	spinnerInstance.Start()
-	stream, err := client.StatusEnvironment(*requestCtx, request)
+	defer spinnerInstance.Stop()
+
+	var status *environment.StatusEnvironmentResponse
+	err := withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		client := environment.NewEnvironmentServiceClient(conn)
+		stream, err := client.StatusEnvironment(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		var response *environment.StatusEnvironmentResponse
+		for {
+			status = response
+			response, err = stream.Recv()
+			if err != nil {
+				if errors.Is(err, context.Canceled) || err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
-	var response *environment.StatusEnvironmentResponse
-	var prevResponse *environment.StatusEnvironmentResponse
+	return status, nil
+}
 
-	for {
-		prevResponse = response
-		response, err = stream.Recv()
+// WatchEnvironmentStatus streams every status of the environment to the writer until the server closes the stream.
+// The stream is opened again when it fails with a transient error, the writer receiving the statuses from the start.
+func (e *Environment) WatchEnvironmentStatus(ctx *context.Context, request *environment.StatusEnvironmentRequest, write StatusWriter) error {
+	return withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
 		if err != nil {
-			if errors.Is(err, context.Canceled) || err == io.EOF {
-				break
+			return err
+		}
+
+		client := environment.NewEnvironmentServiceClient(conn)
+		stream, err := client.StatusEnvironment(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, context.Canceled) || err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			if response != nil {
+				write(response)
 			}
-			return nil, err
 		}
-	}
-	spinnerInstance.Stop()
-	return prevResponse, nil
+	})
 }
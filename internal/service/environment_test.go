@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeActionStream returns the messages in order, then the error
+type fakeActionStream struct {
+	messages []string
+	err      error
+}
+
+func (s *fakeActionStream) Recv() (*environment.CreateEnvironmentResponse, error) {
+	if len(s.messages) == 0 {
+		return nil, s.err
+	}
+	message := s.messages[0]
+	s.messages = s.messages[1:]
+	return &environment.CreateEnvironmentResponse{Message: message}, nil
+}
+
+func TestFollowEnvironmentActionRetries(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+	policy := newRetryPolicy(3)
+	tests := []struct {
+		name      string
+		stream    *fakeActionStream
+		retryable bool
+	}{
+		{name: "before any response", stream: &fakeActionStream{err: unavailable}, retryable: true},
+		{name: "once the action started", stream: &fakeActionStream{messages: []string{"creating"}, err: unavailable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := followEnvironmentAction[*environment.CreateEnvironmentResponse](tt.stream)
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+			assert.Equal(t, tt.retryable, policy.Retryable(err))
+		})
+	}
+}
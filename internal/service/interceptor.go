@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// withRequestMetadata adds the access token and the trace id of the context to the outgoing metadata, a trace id is
+// generated when the context has none
+func withRequestMetadata(ctx context.Context, accessToken string) context.Context {
+	traceID, _ := ctx.Value(constant.TraceIDKey).(string)
+	if traceID == "" {
+		traceID = util.GenerateTraceID()
+	}
+	return metadata.AppendToOutgoingContext(ctx, authorizationKey, accessToken, string(constant.TraceIDKey), traceID)
+}
+
+// metadataUnaryInterceptor adds the request metadata to a unary call
+func (c *connection) metadataUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withRequestMetadata(ctx, c.token()), method, req, reply, cc, opts...)
+}
+
+// metadataStreamInterceptor adds the request metadata to a streaming call
+func (c *connection) metadataStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withRequestMetadata(ctx, c.token()), desc, cc, method, opts...)
+}
+
+// deadlineUnaryInterceptor sets the request timeout on unary calls without a deadline. Streams follow long running
+// actions, so they are only bounded by their context.
+func (c *connection) deadlineUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && c.requestTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout())
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// callLogger returns the function logging the calls made with the context: at info level when verbose is enabled, at
+// debug level otherwise. It returns nil when the calls are not logged.
+func callLogger(ctx context.Context) func(format string, args ...interface{}) {
+	if verbose, _ := ctx.Value(constant.VerboseEnabledKey).(bool); verbose {
+		return log.Infof
+	}
+	if log.IsLevelEnabled(log.DebugLevel) {
+		return log.Debugf
+	}
+	return nil
+}
+
+// loggingUnaryInterceptor logs the request, response and duration of a unary call
+func loggingUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	logf := callLogger(ctx)
+	if logf == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	logf("--> %s %s", method, formatMessage(method, req))
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		logf("<-- %s failed after %s: %v", method, time.Since(start).Round(time.Millisecond), err)
+		return err
+	}
+	logf("<-- %s %s (%s)", method, formatMessage(method, reply), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// loggingStreamInterceptor logs the request of a server streaming call and how the stream ended
+func loggingStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	logf := callLogger(ctx)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if logf == nil {
+		return stream, err
+	}
+	if err != nil {
+		logf("<-- %s failed: %v", method, err)
+		return stream, err
+	}
+	return &loggingClientStream{ClientStream: stream, logf: logf, method: method, start: time.Now()}, nil
+}
+
+// loggingClientStream logs the request sent on the stream and the end of the stream
+type loggingClientStream struct {
+	grpc.ClientStream
+	logf     func(format string, args ...interface{})
+	method   string
+	start    time.Time
+	received int
+}
+
+// SendMsg logs the request
+func (s *loggingClientStream) SendMsg(m interface{}) error {
+	s.logf("--> %s %s", s.method, formatMessage(s.method, m))
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg counts the responses and logs the end of the stream
+func (s *loggingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.received++
+	case errors.Is(err, io.EOF):
+		s.logf("<-- %s closed after %d response(s) in %s", s.method, s.received, time.Since(s.start).Round(time.Millisecond))
+	default:
+		s.logf("<-- %s failed after %d response(s) in %s: %v", s.method, s.received, time.Since(s.start).Round(time.Millisecond), err)
+	}
+	return err
+}
+
+// redactedMessage replaces the requests and responses of the auth service in the logs, as they carry the tokens of
+// the auth provider and the access token
+const redactedMessage = "[redacted]"
+
+// formatMessage formats a request or response of the method on a single line
+func formatMessage(method string, m interface{}) string {
+	if strings.HasPrefix(method, authServicePrefix) {
+		return redactedMessage
+	}
+	if message, ok := m.(proto.Message); ok {
+		data, err := protojson.Marshal(message)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", m)
+}
@@ -5,32 +5,102 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/dream-horizon-org/odin/pkg/constant"
-	"github.com/dream-horizon-org/odin/pkg/util"
 	logs "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// restrictedLogLevels are hidden from live logs unless verbose logging is enabled
 var restrictedLogLevels = []string{"DEBUG", "WARN"}
 
 // Logs performs operation on logs like get logs
 type Logs struct{}
 
-// GetLogs retrieves and displays logs for a service
-func (l *Logs) GetLogs(ctx *context.Context, request *logs.GetLogsRequest) ([]int64, error) {
+// LogFilter decides whether a log of the given level should be shown
+type LogFilter func(level string) bool
+
+// LogWriter writes a single log
+type LogWriter func(logMessage *logs.Log)
+
+// LogOptions controls which logs are shown and how they are written. Received, when set, is called for every log
+// received, shown or not.
+type LogOptions struct {
+	Filter   LogFilter
+	Write    LogWriter
+	Received func()
+}
+
+// IncludeLevels shows only logs of the given levels, all logs are shown when no level is given
+func IncludeLevels(levels []string) LogFilter {
+	return func(level string) bool {
+		if len(levels) == 0 {
+			return true
+		}
+		for _, allowed := range levels {
+			if strings.EqualFold(allowed, level) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ExcludeLevels hides logs of the given levels
+func ExcludeLevels(levels []string) LogFilter {
+	return func(level string) bool {
+		for _, excluded := range levels {
+			if strings.EqualFold(excluded, level) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// PrintLogMessage prints the log message as is
+func PrintLogMessage(logMessage *logs.Log) {
+	fmt.Println(logMessage.GetMessage())
+}
+
+// defaultLogOptions returns the options used for live logs while an action is running
+func defaultLogOptions(ctx *context.Context) LogOptions {
+	verboseEnabled := false
+	if (*ctx).Value(constant.VerboseEnabledKey) != nil {
+		verboseEnabled = (*ctx).Value(constant.VerboseEnabledKey).(bool)
+	}
+	filter := ExcludeLevels(restrictedLogLevels)
+	if verboseEnabled {
+		filter = IncludeLevels(nil)
+	}
+	return LogOptions{Filter: filter, Write: PrintLogMessage}
+}
+
+// GetLogs retrieves logs for a service and writes the ones passing the filter, resuming after the last log received
+// when the stream fails with a transient error.
+// It returns the search after params of the last log received, to be used for the next page.
+func (l *Logs) GetLogs(ctx *context.Context, request *logs.GetLogsRequest, options LogOptions) ([]int64, error) {
+	searchAfterParams := request.GetSearchAfterParams()
+	err := withRetries(ctx, func() error {
+		attempt := proto.Clone(request).(*logs.GetLogsRequest)
+		attempt.SearchAfterParams = searchAfterParams
+		var err error
+		searchAfterParams, err = l.readLogs(ctx, attempt, options)
+		return err
+	})
+	return searchAfterParams, err
+}
+
+// readLogs reads a single stream of logs
+func (l *Logs) readLogs(ctx *context.Context, request *logs.GetLogsRequest, options LogOptions) ([]int64, error) {
 	conn, requestCtx, err := grpcClient(ctx)
 	if err != nil {
 		return request.GetSearchAfterParams(), err
 	}
-	defer func() {
-		err := conn.Close()
-		if err != nil {
-			fmt.Printf("Error closing connection: %v\n", err)
-		}
-	}()
 
 	client := logs.NewLogsServiceClient(conn)
 	stream, err := client.GetLogs(*requestCtx, request)
@@ -46,7 +116,8 @@ func (l *Logs) GetLogs(ctx *context.Context, request *logs.GetLogsRequest) ([]in
 			if errors.Is(err, context.Canceled) || err == io.EOF {
 				break
 			}
-			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			// Logs may not be indexed yet while an action is starting, wait for them when following
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound && request.GetFollow() {
 				time.Sleep(5 * time.Second)
 				stream, err = client.GetLogs(*requestCtx, request)
 				if err != nil {
@@ -61,18 +132,13 @@ func (l *Logs) GetLogs(ctx *context.Context, request *logs.GetLogsRequest) ([]in
 			continue
 		}
 
-		verboseEnabled := false
-		if (*ctx).Value(constant.VerboseEnabledKey) != nil {
-			verboseEnabled = (*ctx).Value(constant.VerboseEnabledKey).(bool)
-			if verboseEnabled {
-				restrictedLogLevels = []string{}
-			}
-		}
-
 		for _, logMessage := range response.Logs {
-			if !util.Contains(logMessage.GetLevel(), restrictedLogLevels) {
-				fmt.Println(logMessage.GetMessage())
-				searchAfterParams = logMessage.GetSearchAfterParams()
+			searchAfterParams = logMessage.GetSearchAfterParams()
+			if options.Received != nil {
+				options.Received()
+			}
+			if options.Filter == nil || options.Filter(logMessage.GetLevel()) {
+				options.Write(logMessage)
 			}
 		}
 	}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	logs "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
+	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of the progress events
+const (
+	StatusEvent  = "status"
+	LogEvent     = "log"
+	ErrorEvent   = "error"
+	SummaryEvent = "summary"
+)
+
+// ProgressEvent is a machine readable event of a deploy, undeploy or operate action, printed as one JSON object per
+// line with -o json
+type ProgressEvent struct {
+	Type       string              `json:"type"`
+	Time       time.Time           `json:"time"`
+	TraceID    string              `json:"traceId"`
+	Service    string              `json:"service,omitempty"`
+	Version    string              `json:"version,omitempty"`
+	Action     string              `json:"action,omitempty"`
+	Status     string              `json:"status,omitempty"`
+	Components []ComponentProgress `json:"components,omitempty"`
+	Level      string              `json:"level,omitempty"`
+	Message    string              `json:"message,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// ComponentProgress is the status of a component in a progress event
+type ComponentProgress struct {
+	Name   string `json:"name"`
+	Action string `json:"action,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// progress reports the progress of an action as events when the json output format is used, the text output is left to
+// the callers otherwise
+type progress struct {
+	events  bool
+	traceID string
+	now     func() time.Time
+
+	mu      sync.Mutex
+	encoder *json.Encoder
+	last    *serviceProto.ServiceResponse
+}
+
+// newProgress returns the progress of the action of the context, writing events to stdout with the json output format
+func newProgress(ctx *context.Context) *progress {
+	outputFormat, _ := (*ctx).Value(constant.OutputFormatKey).(string)
+	traceID, _ := (*ctx).Value(constant.TraceIDKey).(string)
+	return newProgressWriter(os.Stdout, outputFormat == constant.JSON, traceID, time.Now)
+}
+
+func newProgressWriter(writer io.Writer, events bool, traceID string, now func() time.Time) *progress {
+	return &progress{events: events, traceID: traceID, now: now, encoder: json.NewEncoder(writer)}
+}
+
+// status reports a message of the action stream
+func (p *progress) status(response *serviceProto.ServiceResponse) {
+	p.mu.Lock()
+	p.last = response
+	p.mu.Unlock()
+	if !p.events {
+		return
+	}
+	var components []ComponentProgress
+	for _, component := range response.GetComponentsStatus() {
+		components = append(components, ComponentProgress{
+			Name:   component.GetComponentName(),
+			Action: component.GetComponentAction(),
+			Status: component.GetComponentStatus(),
+			Error:  component.GetError(),
+		})
+	}
+	p.write(ProgressEvent{
+		Type:       StatusEvent,
+		Service:    response.GetName(),
+		Version:    response.GetVersion(),
+		Action:     response.GetServiceStatus().GetServiceAction(),
+		Status:     response.GetServiceStatus().GetServiceStatus(),
+		Components: components,
+		Message:    response.GetMessage(),
+	})
+}
+
+// log reports a log of the action
+func (p *progress) log(logMessage *logs.Log) {
+	p.write(ProgressEvent{
+		Type:    LogEvent,
+		Level:   logMessage.GetLevel(),
+		Message: logMessage.GetMessage(),
+	})
+}
+
+// finish reports the error the action failed with, if any, followed by the summary of the action
+func (p *progress) finish(err error) {
+	if !p.events {
+		return
+	}
+	p.mu.Lock()
+	last := p.last
+	p.mu.Unlock()
+
+	summary := ProgressEvent{
+		Type:    SummaryEvent,
+		Service: last.GetName(),
+		Version: last.GetVersion(),
+		Action:  last.GetServiceStatus().GetServiceAction(),
+		Status:  last.GetServiceStatus().GetServiceStatus(),
+	}
+	if err != nil {
+		p.write(ProgressEvent{Type: ErrorEvent, Error: err.Error()})
+		summary.Status = "FAILED"
+		summary.Error = err.Error()
+	}
+	p.write(summary)
+}
+
+func (p *progress) write(event ProgressEvent) {
+	if !p.events {
+		return
+	}
+	event.Time = p.now().UTC()
+	event.TraceID = p.traceID
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.encoder.Encode(event); err != nil {
+		log.Errorf("Error writing progress event: %v", err)
+	}
+}
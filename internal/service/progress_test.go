@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	logs "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
+	serviceProto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var progressTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func serviceResponse(serviceStatus string, components ...*serviceProto.ComponentStatus) *serviceProto.ServiceResponse {
+	return &serviceProto.ServiceResponse{
+		Name:             "orders",
+		Version:          "1.0.0",
+		ServiceStatus:    &serviceProto.ServiceStatus{ServiceAction: "DEPLOY", ServiceStatus: serviceStatus},
+		ComponentsStatus: components,
+	}
+}
+
+func readEvents(t *testing.T, buffer *bytes.Buffer) []ProgressEvent {
+	var events []ProgressEvent
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var event ProgressEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestProgressEvents(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := newProgressWriter(&buffer, true, "trace", func() time.Time { return progressTime })
+
+	progress.status(serviceResponse("IN_PROGRESS", &serviceProto.ComponentStatus{ComponentName: "app", ComponentAction: "DEPLOY", ComponentStatus: "IN_PROGRESS"}))
+	level := "INFO"
+	progress.log(&logs.Log{Message: "pulling image", Level: &level})
+	progress.status(serviceResponse("SUCCESSFUL", &serviceProto.ComponentStatus{ComponentName: "app", ComponentAction: "DEPLOY", ComponentStatus: "SUCCESSFUL"}))
+	progress.finish(nil)
+
+	assert.Equal(t, []ProgressEvent{
+		{
+			Type: StatusEvent, Time: progressTime, TraceID: "trace", Service: "orders", Version: "1.0.0", Action: "DEPLOY", Status: "IN_PROGRESS",
+			Components: []ComponentProgress{{Name: "app", Action: "DEPLOY", Status: "IN_PROGRESS"}},
+		},
+		{Type: LogEvent, Time: progressTime, TraceID: "trace", Level: "INFO", Message: "pulling image"},
+		{
+			Type: StatusEvent, Time: progressTime, TraceID: "trace", Service: "orders", Version: "1.0.0", Action: "DEPLOY", Status: "SUCCESSFUL",
+			Components: []ComponentProgress{{Name: "app", Action: "DEPLOY", Status: "SUCCESSFUL"}},
+		},
+		{Type: SummaryEvent, Time: progressTime, TraceID: "trace", Service: "orders", Version: "1.0.0", Action: "DEPLOY", Status: "SUCCESSFUL"},
+	}, readEvents(t, &buffer))
+}
+
+func TestProgressFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := newProgressWriter(&buffer, true, "trace", func() time.Time { return progressTime })
+
+	progress.status(serviceResponse("IN_PROGRESS", &serviceProto.ComponentStatus{ComponentName: "app", ComponentStatus: "FAILED", Error: "image not found"}))
+	progress.finish(errors.New("stream closed"))
+
+	events := readEvents(t, &buffer)
+	require.Len(t, events, 3)
+	assert.Equal(t, "image not found", events[0].Components[0].Error)
+	assert.Equal(t, ProgressEvent{Type: ErrorEvent, Time: progressTime, TraceID: "trace", Error: "stream closed"}, events[1])
+	assert.Equal(t, ProgressEvent{
+		Type: SummaryEvent, Time: progressTime, TraceID: "trace", Service: "orders", Version: "1.0.0", Action: "DEPLOY",
+		Status: "FAILED", Error: "stream closed",
+	}, events[2])
+}
+
+func TestProgressText(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := newProgressWriter(&buffer, false, "trace", time.Now)
+
+	progress.status(serviceResponse("SUCCESSFUL"))
+	progress.log(&logs.Log{Message: "pulling image"})
+	progress.finish(nil)
+
+	assert.Empty(t, buffer.String())
+}
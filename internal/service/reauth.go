@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dream-horizon-org/odin/internal/auth"
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/term"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authServicePrefix is the method prefix of the auth service, which never triggers re-authentication
+	authServicePrefix = "/dream11.od.auth.v1.AuthService/"
+	// authorizationKey is the metadata key carrying the access token
+	authorizationKey = "authorization"
+)
+
+var (
+	reauthMutex       sync.Mutex
+	refreshedToken    string
+	expiryWarningOnce sync.Once
+)
+
+// authenticate runs the auth provider flow and saves the new token, it is set in init as the flow itself calls the
+// backend through the interceptors
+var authenticate func(ctx context.Context) (string, error)
+
+func init() {
+	authenticate = authenticateAndSave
+}
+
+// authenticateAndSave runs the auth provider flow and saves the new token to the active profile
+func authenticateAndSave(ctx context.Context) (string, error) {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	token, err := (&Configure{}).Authenticate(&ctx, appConfig.OrgId, false)
+	if err != nil {
+		return "", err
+	}
+	return token, config.UpdateAccessToken(token)
+}
+
+// interactive checks if the user can go through the auth provider flow
+var interactive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// warnIfTokenExpiring warns once per run when the access token is expired or about to expire
+func warnIfTokenExpiring(accessToken string) {
+	expiry, ok := auth.TokenExpiry(accessToken)
+	if !ok {
+		return
+	}
+	remaining := time.Until(expiry)
+	if remaining > constant.TokenExpiryWarningWindow {
+		return
+	}
+	expiryWarningOnce.Do(func() {
+		if remaining <= 0 {
+			log.Warnf("Access token expired at %s, re-authentication will be required", expiry.Local().Format(time.RFC1123))
+			return
+		}
+		log.Warnf("Access token expires in %s, run `odin configure` to refresh it", remaining.Round(time.Second))
+	})
+}
+
+// shouldReauthenticate checks if the call failed because of an invalid or expired token
+func shouldReauthenticate(method string, err error) bool {
+	if err == nil || strings.HasPrefix(method, authServicePrefix) {
+		return false
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unauthenticated
+}
+
+// reauthenticate re-runs the auth provider flow once per run and saves the new token to the active profile
+func reauthenticate(ctx context.Context, failedToken string) (string, error) {
+	reauthMutex.Lock()
+	defer reauthMutex.Unlock()
+
+	// Another call already refreshed the token
+	if refreshedToken != "" && refreshedToken != failedToken {
+		return refreshedToken, nil
+	}
+	if config.AccessTokenFromEnvironment() {
+		return "", status.Errorf(codes.Unauthenticated, "access token from %s or %s is invalid or expired", constant.AccessTokenEnv, constant.TokenFileEnv)
+	}
+	if !interactive() {
+		return "", status.Error(codes.Unauthenticated, "access token is invalid or expired, run `odin configure` to authenticate again")
+	}
+
+	log.Warn("Access token is invalid or expired, re-authenticating...")
+	authCtx := context.Background()
+	if traceID := ctx.Value(constant.TraceIDKey); traceID != nil {
+		authCtx = context.WithValue(authCtx, constant.TraceIDKey, traceID)
+	}
+	token, err := authenticate(authCtx)
+	if err != nil {
+		return "", err
+	}
+	refreshedToken = token
+	return token, nil
+}
+
+// withRefreshedToken returns a copy of the outgoing context carrying a new access token
+func withRefreshedToken(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	var failedToken string
+	if values := md.Get(authorizationKey); len(values) > 0 {
+		failedToken = values[0]
+	}
+	token, err := reauthenticate(ctx, failedToken)
+	if err != nil {
+		return nil, err
+	}
+	md.Set(authorizationKey, token)
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+// reauthUnaryInterceptor retries a unary call once with a new token when it fails as unauthenticated
+func reauthUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if !shouldReauthenticate(method, err) {
+		return err
+	}
+	retryCtx, reauthErr := withRefreshedToken(ctx)
+	if reauthErr != nil {
+		return reauthErr
+	}
+	return invoker(retryCtx, method, req, reply, cc, opts...)
+}
+
+// reauthStreamInterceptor retries a server streaming call once with a new token when it fails as unauthenticated
+func reauthStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if shouldReauthenticate(method, err) {
+		retryCtx, reauthErr := withRefreshedToken(ctx)
+		if reauthErr != nil {
+			return nil, reauthErr
+		}
+		return streamer(retryCtx, desc, cc, method, opts...)
+	}
+	if err != nil || desc.ClientStreams || strings.HasPrefix(method, authServicePrefix) {
+		return stream, err
+	}
+	return &reauthClientStream{
+		ClientStream: stream,
+		ctx:          ctx,
+		desc:         desc,
+		cc:           cc,
+		method:       method,
+		streamer:     streamer,
+		opts:         opts,
+	}, nil
+}
+
+// reauthClientStream replays the request on a new stream when the first receive fails as unauthenticated
+type reauthClientStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	desc     *grpc.StreamDesc
+	cc       *grpc.ClientConn
+	method   string
+	streamer grpc.Streamer
+	opts     []grpc.CallOption
+	request  interface{}
+	received bool
+	retried  bool
+}
+
+// SendMsg keeps the request so that it can be replayed
+func (s *reauthClientStream) SendMsg(m interface{}) error {
+	s.request = m
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg receives a message, re-authenticating and replaying the request once if needed
+func (s *reauthClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.received = true
+		return nil
+	}
+	if s.received || s.retried || s.request == nil || !shouldReauthenticate(s.method, err) {
+		return err
+	}
+	s.retried = true
+
+	retryCtx, reauthErr := withRefreshedToken(s.ctx)
+	if reauthErr != nil {
+		return reauthErr
+	}
+	stream, err := s.streamer(retryCtx, s.desc, s.cc, s.method, s.opts...)
+	if err != nil {
+		return err
+	}
+	if err := stream.SendMsg(s.request); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+	s.ClientStream = stream
+	return s.RecvMsg(m)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "token expired")
+
+// stubReauth replaces the auth provider flow with one returning the token, counting its runs
+func stubReauth(t *testing.T, token string, isInteractive bool) *int {
+	t.Setenv(constant.AccessTokenEnv, "")
+	t.Setenv(constant.TokenFileEnv, "")
+	runs := 0
+	previousAuthenticate, previousInteractive := authenticate, interactive
+	authenticate = func(ctx context.Context) (string, error) {
+		runs++
+		return token, nil
+	}
+	interactive = func() bool { return isInteractive }
+	refreshedToken = ""
+	t.Cleanup(func() {
+		authenticate, interactive = previousAuthenticate, previousInteractive
+		refreshedToken = ""
+	})
+	return &runs
+}
+
+// outgoingToken returns the access token of the outgoing metadata
+func outgoingToken(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if values := md.Get(authorizationKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func TestShouldReauthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		expected bool
+	}{
+		{name: "success", method: "/test"},
+		{name: "unauthenticated", method: "/test", err: errUnauthenticated, expected: true},
+		{name: "other code", method: "/test", err: status.Error(codes.PermissionDenied, "denied")},
+		{name: "not a status", method: "/test", err: errors.New("unauthenticated")},
+		{name: "auth service", method: authServicePrefix + "GetUserToken", err: errUnauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, shouldReauthenticate(tt.method, tt.err))
+		})
+	}
+}
+
+func TestReauthenticate(t *testing.T) {
+	runs := stubReauth(t, "refreshed", true)
+
+	token, err := reauthenticate(context.Background(), "expired")
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", token)
+
+	// Calls that failed with the expired token reuse the refreshed one
+	token, err = reauthenticate(context.Background(), "expired")
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", token)
+	assert.Equal(t, 1, *runs)
+
+	// The refreshed token failing too runs the flow again
+	_, err = reauthenticate(context.Background(), "refreshed")
+	require.NoError(t, err)
+	assert.Equal(t, 2, *runs)
+}
+
+func TestReauthenticateRefused(t *testing.T) {
+	runs := stubReauth(t, "refreshed", false)
+	_, err := reauthenticate(context.Background(), "expired")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, "odin configure")
+
+	interactive = func() bool { return true }
+	t.Setenv(constant.AccessTokenEnv, "from-environment")
+	_, err = reauthenticate(context.Background(), "expired")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, constant.AccessTokenEnv)
+	assert.Zero(t, *runs)
+}
+
+func TestWithRefreshedToken(t *testing.T) {
+	stubReauth(t, "refreshed", true)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "expired", string(constant.TraceIDKey), "trace")
+
+	refreshed, err := withRefreshedToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", outgoingToken(refreshed))
+	md, _ := metadata.FromOutgoingContext(refreshed)
+	assert.Equal(t, []string{"trace"}, md.Get(string(constant.TraceIDKey)))
+	// The original context is left untouched
+	assert.Equal(t, "expired", outgoingToken(ctx))
+}
+
+func TestReauthUnaryInterceptor(t *testing.T) {
+	runs := stubReauth(t, "refreshed", true)
+	var tokens []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		tokens = append(tokens, outgoingToken(ctx))
+		if outgoingToken(ctx) == "expired" {
+			return errUnauthenticated
+		}
+		return nil
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "expired")
+
+	require.NoError(t, reauthUnaryInterceptor(ctx, "/test", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"expired", "refreshed"}, tokens)
+	assert.Equal(t, 1, *runs)
+
+	// The auth service is never retried
+	tokens = nil
+	assert.Equal(t, errUnauthenticated, reauthUnaryInterceptor(ctx, authServicePrefix+"GetUserToken", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"expired"}, tokens)
+}
+
+// fakeClientStream fails its first receive with the error, then returns io.EOF
+type fakeClientStream struct {
+	grpc.ClientStream
+	err  error
+	sent []interface{}
+}
+
+func (s *fakeClientStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *fakeClientStream) CloseSend() error { return nil }
+
+func (s *fakeClientStream) RecvMsg(m interface{}) error {
+	if err := s.err; err != nil {
+		s.err = nil
+		return err
+	}
+	return io.EOF
+}
+
+func TestReauthClientStream(t *testing.T) {
+	runs := stubReauth(t, "refreshed", true)
+	var streams []*fakeClientStream
+	var tokens []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		tokens = append(tokens, outgoingToken(ctx))
+		stream := &fakeClientStream{}
+		if outgoingToken(ctx) == "expired" {
+			stream.err = errUnauthenticated
+		}
+		streams = append(streams, stream)
+		return stream, nil
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "expired")
+
+	stream, err := reauthStreamInterceptor(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/test", streamer)
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg("request"))
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+
+	assert.Equal(t, []string{"expired", "refreshed"}, tokens)
+	require.Len(t, streams, 2)
+	assert.Equal(t, []interface{}{"request"}, streams[1].sent, "request not replayed on the new stream")
+	assert.Equal(t, 1, *runs)
+}
+
+func TestReauthClientStreamRetriesOnce(t *testing.T) {
+	runs := stubReauth(t, "refreshed", true)
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{err: errUnauthenticated}, nil
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "expired")
+
+	stream, err := reauthStreamInterceptor(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/test", streamer)
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg("request"))
+	assert.Equal(t, errUnauthenticated, stream.RecvMsg(nil))
+	assert.Equal(t, 1, *runs)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/config"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/retryable"
+	log "github.com/sirupsen/logrus"
+)
+
+// newRetryPolicy returns the policy retrying the calls to the backend which fail with a transient error
+func newRetryPolicy(retries int) retryable.Policy {
+	return retryable.Policy{
+		MaxAttempts:    retries + 1,
+		InitialBackoff: constant.RetryInitialBackoff,
+		MaxBackoff:     constant.RetryMaxBackoff,
+		Jitter:         constant.RetryJitter,
+		RetryableCodes: RetryableStatusCodes,
+		OnRetry: func(retry int, err error, wait time.Duration) {
+			if retry == 1 {
+				log.Warnf("%s %v", constant.InitiatingRetryMessage, err)
+			}
+			log.Infof(constant.RetryingMessage, retry, retries)
+		},
+	}
+}
+
+// withRetries calls the function with the retries of the active profile, returning an unavailable error once they
+// are exhausted
+func withRetries(ctx *context.Context, call func() error) error {
+	appConfig, err := config.GetConfig()
+	if err != nil {
+		return err
+	}
+	retries, err := config.Retries(appConfig)
+	if err != nil {
+		return err
+	}
+	return retryResult(newRetryPolicy(retries).Do(*ctx, call))
+}
+
+func retryResult(err error) error {
+	var exhausted *retryable.ExhaustedError
+	if errors.As(err, &exhausted) {
+		return exitcode.New(exitcode.Unavailable, fmt.Errorf("%w\n%s", exhausted, constant.MaxRetriesReached))
+	}
+	return err
+}
@@ -2,14 +2,14 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
 	"time"
 
-	"github.com/avast/retry-go"
 	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/envstatus"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	"github.com/dream-horizon-org/odin/pkg/retryable"
 	"github.com/dream-horizon-org/odin/pkg/util"
 	logs "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/logs/v1"
@@ -23,13 +23,18 @@ import (
 // Service performs operation on service like deploy. undeploy
 type Service struct{}
 
-var logsClient = Logs{}
+// logReader reads the logs of an action
+type logReader interface {
+	GetLogs(ctx *context.Context, request *logs.GetLogsRequest, options LogOptions) ([]int64, error)
+}
+
+var logsClient logReader = &Logs{}
 
 var serviceTerminalConditions = map[string][]string{
-	"DEPLOY":   {"SUCCESSFUL", "FAILED"},
-	"UNDEPLOY": {"SUCCESSFUL", "FAILED"},
-	"OPERATE":  {"SUCCESSFUL", "FAILED"},
-	"VALIDATE": {"FAILED"},
+	"DEPLOY":   {envstatus.Successful, envstatus.Failed},
+	"UNDEPLOY": {envstatus.Successful, envstatus.Failed},
+	"OPERATE":  {envstatus.Successful, envstatus.Failed},
+	"VALIDATE": {envstatus.Failed},
 }
 
 // RetryableStatusCodes are the status codes that are retryable
@@ -40,143 +45,120 @@ type StreamReceiverInterface[R any] interface {
 	Recv() (R, error)
 }
 
-type getStatus[R any] func(response R) (serviceAction, serviceStatus string)
-
-type getMessage[R any] func(response R) string
+type getServiceResponse[R any] func(response R) *serviceProto.ServiceResponse
 
 // DeployService deploys service
-func (e *Service) DeployService(ctx *context.Context, request *serviceProto.DeployServiceRequest) error {
+func (e *Service) DeployService(ctx *context.Context, request *serviceProto.DeployServiceRequest) (err error) {
 	log.Infof(constant.ServiceExecutionMessageTemplate, "Deploying", request.GetServiceDefinition().GetName(), request.GetEnvName())
 
+	// Report the progress, and the summary once the log streaming is cancelled
+	progress := newProgress(ctx)
+	defer func() { progress.finish(err) }()
+	drain := newLogDrain(ctx)
+
 	// Create a context with cancelFunction for the entire operation
 	streamCtx, cancelFunction := context.WithCancel(context.Background())
 	defer cancelFunction()
 
 	// Start log streaming in background
-	go streamLogs(streamCtx, ctx, request.GetServiceDefinition().GetName())
+	go streamLogs(streamCtx, ctx, progress, drain, request.GetServiceDefinition().GetName())
 
 	// Attempt deployment with retries
-	return retry.Do(
-		func() error {
-			conn, requestCtx, err := grpcClient(ctx)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				err := conn.Close()
-				if err != nil {
-					log.Errorf("Error closing connection: %v\n", err)
-				}
-			}()
-
-			client := serviceProto.NewServiceServiceClient(conn)
-			stream, err := client.DeployService(*requestCtx, request)
-			if err != nil {
-				return err
-			}
-			getMessage := func(response *serviceProto.DeployServiceResponse) string {
-				return util.GenerateResponseMessage(response.GetServiceResponse())
-			}
-			getStatus := func(response *serviceProto.DeployServiceResponse) (string, string) {
-				return response.GetServiceResponse().GetServiceStatus().GetServiceStatus(),
-					response.GetServiceResponse().GetServiceStatus().GetServiceAction()
-			}
+	return withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
 
-			return handleResponse(stream, cancelFunction, getMessage, getStatus)
-		},
-		retry.Delay(constant.Delay),
-		retry.RetryIf(isRetryableError),
-	)
+		client := serviceProto.NewServiceServiceClient(conn)
+		stream, err := client.DeployService(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		return handleResponse(stream, cancelFunction, progress, drain, (*serviceProto.DeployServiceResponse).GetServiceResponse)
+	})
 }
 
 // UndeployService undeploy service
-func (e *Service) UndeployService(ctx *context.Context, request *serviceProto.UndeployServiceRequest) error {
+func (e *Service) UndeployService(ctx *context.Context, request *serviceProto.UndeployServiceRequest) (err error) {
 	log.Infof(constant.ServiceExecutionMessageTemplate, "Undeploying", request.GetServiceName(), request.GetEnvName())
 	traceID := util.GenerateTraceID()
 	contextWithTrace := context.WithValue(*ctx, constant.TraceIDKey, traceID)
 
+	// Report the progress, and the summary once the log streaming is cancelled
+	progress := newProgress(&contextWithTrace)
+	defer func() { progress.finish(err) }()
+	drain := newLogDrain(ctx)
+
 	// Create a context with cancelFunction for the entire operation
 	streamCtx, cancelFunction := context.WithCancel(context.Background())
 	defer cancelFunction()
 
 	// Start log streaming in background
-	go streamLogs(streamCtx, &contextWithTrace, request.GetServiceName())
-
-	conn, requestCtx, err := grpcClient(&contextWithTrace)
-	if err != nil {
-		return err
-	}
-
-	client := serviceProto.NewServiceServiceClient(conn)
-	stream, err := client.UndeployService(*requestCtx, request)
+	go streamLogs(streamCtx, &contextWithTrace, progress, drain, request.GetServiceName())
 
-	if err != nil {
-		return err
-	}
-
-	getMessage := func(response *serviceProto.UndeployServiceResponse) string {
-		return util.GenerateResponseMessage(response.GetServiceResponse())
-	}
-	getStatus := func(response *serviceProto.UndeployServiceResponse) (string, string) {
-		return response.GetServiceResponse().GetServiceStatus().GetServiceStatus(),
-			response.GetServiceResponse().GetServiceStatus().GetServiceAction()
-	}
+	// Attempt undeployment with retries
+	return withRetries(&contextWithTrace, func() error {
+		conn, requestCtx, err := grpcClient(&contextWithTrace)
+		if err != nil {
+			return err
+		}
 
-	return handleResponse(stream, cancelFunction, getMessage, getStatus)
+		client := serviceProto.NewServiceServiceClient(conn)
+		stream, err := client.UndeployService(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		return handleResponse(stream, cancelFunction, progress, drain, (*serviceProto.UndeployServiceResponse).GetServiceResponse)
+	})
 }
 
 // OperateService :service operations
-func (e *Service) OperateService(ctx *context.Context, request *serviceProto.OperateServiceRequest) error {
+func (e *Service) OperateService(ctx *context.Context, request *serviceProto.OperateServiceRequest) (err error) {
 	log.Infof(constant.ServiceExecutionMessageTemplate, "Operating", request.GetServiceName(), request.GetEnvName())
 
+	// Report the progress, and the summary once the log streaming is cancelled
+	progress := newProgress(ctx)
+	defer func() { progress.finish(err) }()
+	drain := newLogDrain(ctx)
+
 	// Create a context with cancelFunction for the entire operation
 	streamCtx, cancelFunction := context.WithCancel(context.Background())
 	defer cancelFunction()
 
 	// Start log streaming in background
-	go streamLogs(streamCtx, ctx, request.GetServiceName())
+	go streamLogs(streamCtx, ctx, progress, drain, request.GetServiceName())
 
 	// Attempt operation with retries
-	return retry.Do(
-		func() error {
-			conn, requestCtx, err := grpcClient(ctx)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				err := conn.Close()
-				if err != nil {
-					log.Errorf("Error closing connection: %v\n", err)
-				}
-			}()
-
-			client := serviceProto.NewServiceServiceClient(conn)
-			stream, err := client.OperateService(*requestCtx, request)
-			if err != nil {
-				return err
-			}
-			getMessage := func(response *serviceProto.OperateServiceResponse) string {
-				return util.GenerateResponseMessage(response.GetServiceResponse())
-			}
-			getStatus := func(response *serviceProto.OperateServiceResponse) (string, string) {
-				return response.GetServiceResponse().GetServiceStatus().GetServiceStatus(),
-					response.GetServiceResponse().GetServiceStatus().GetServiceAction()
-			}
+	return withRetries(ctx, func() error {
+		conn, requestCtx, err := grpcClient(ctx)
+		if err != nil {
+			return err
+		}
 
-			return handleResponse(stream, cancelFunction, getMessage, getStatus)
-		},
-		retry.Delay(constant.Delay),
-		retry.RetryIf(isRetryableError),
-	)
+		client := serviceProto.NewServiceServiceClient(conn)
+		stream, err := client.OperateService(*requestCtx, request)
+		if err != nil {
+			return err
+		}
+		return handleResponse(stream, cancelFunction, progress, drain, (*serviceProto.OperateServiceResponse).GetServiceResponse)
+	})
 }
 
-// streamLogs streams logs for a service
-func streamLogs(streamCtx context.Context, ctx *context.Context, serviceName string) {
+// streamLogs streams logs for a service until the stream context is cancelled or, once the action completed, the
+// backend ends the logs
+func streamLogs(streamCtx context.Context, ctx *context.Context, progress *progress, drain *logDrain, serviceName string) {
 	var err error
 	var searchAfterParams []int64
 	traceID := (*ctx).Value(constant.TraceIDKey).(string)
 	follow := true
-	fmt.Printf("Fetching live logs for service: %s \n", serviceName)
+	logOptions := defaultLogOptions(ctx)
+	logOptions.Received = drain.receive
+	if progress.events {
+		logOptions.Write = progress.log
+	} else {
+		fmt.Printf("Fetching live logs for service: %s \n", serviceName)
+	}
 	for {
 		select {
 		case <-streamCtx.Done():
@@ -188,23 +170,36 @@ func streamLogs(streamCtx context.Context, ctx *context.Context, serviceName str
 				Follow:            &follow,
 				ServiceName:       &serviceName,
 				SearchAfterParams: searchAfterParams,
-			})
+			}, logOptions)
 			if err != nil {
+				// GetLogs already retried transient errors, wait before following the logs again
+				select {
+				case <-streamCtx.Done():
+					return
+				case <-time.After(constant.RetryMaxBackoff):
+				}
 				continue
 			}
+			// The backend closes the followed logs once all the logs of a completed action were sent
+			if drain.completed() {
+				drain.end()
+				return
+			}
 		}
 	}
 }
 
-// handleResponse streams the service deploy response and call cancel on action termination
-func handleResponse[S StreamReceiverInterface[R], R any](stream S, cancelFunc context.CancelFunc, getMessage getMessage[R], getStatus getStatus[R]) error {
+// handleResponse streams the service deploy response, reports it as progress and call cancel on action termination,
+// once the remaining logs are drained
+func handleResponse[S StreamReceiverInterface[R], R any](stream S, cancelFunc context.CancelFunc, progress *progress, drain *logDrain, getServiceResponse getServiceResponse[R]) error {
 	var serviceAction, serviceStatus string
+	var serviceResponse *serviceProto.ServiceResponse
 	for {
 		response, err := stream.Recv()
 		if err != nil {
 			if isActionCompleted(serviceAction, serviceStatus) {
 				cancelFunc()
-				return nil
+				return actionResult(serviceResponse)
 			}
 
 			st, _ := status.FromError(err)
@@ -216,18 +211,31 @@ func handleResponse[S StreamReceiverInterface[R], R any](stream S, cancelFunc co
 			cancelFunc()
 			return err
 		}
-		serviceStatus, serviceAction = getStatus(response)
+		serviceResponse = getServiceResponse(response)
+		progress.status(serviceResponse)
+		serviceStatus = serviceResponse.GetServiceStatus().GetServiceStatus()
+		serviceAction = serviceResponse.GetServiceStatus().GetServiceAction()
 		if isActionCompleted(serviceAction, serviceStatus) {
-			// Wait for few seconds to ensure all logs are received
-			log.Info(getMessage(response))
-			log.Info(constant.CheckingAdditionalLogsMessage)
-			time.Sleep(30 * time.Second)
+			if !progress.events {
+				log.Info(util.GenerateResponseMessage(serviceResponse))
+				log.Info(constant.CheckingAdditionalLogsMessage)
+			}
+			drain.wait()
 			cancelFunc()
-			return nil
+			return actionResult(serviceResponse)
 		}
 	}
 }
 
+// actionResult returns an error when the completed action of the service FAILED
+func actionResult(response *serviceProto.ServiceResponse) error {
+	if response.GetServiceStatus().GetServiceStatus() != envstatus.Failed {
+		return nil
+	}
+	return exitcode.Errorf(exitcode.DeploymentFailed, "%s of service %s FAILED",
+		strings.ToLower(response.GetServiceStatus().GetServiceAction()), response.GetName())
+}
+
 // isActionCompleted checks if the action is completed based on the service action and status
 func isActionCompleted(serviceAction, status string) bool {
 	if serviceAction == "" || status == "" {
@@ -235,13 +243,3 @@ func isActionCompleted(serviceAction, status string) bool {
 	}
 	return slices.Contains(serviceTerminalConditions[serviceAction], status)
 }
-
-// isRetryableError checks if the error is retryable
-func isRetryableError(err error) bool {
-	var re retryable.Error
-	if errors.As(err, &re) && re.Retryable() {
-		log.Info("Connection lost, retrying...")
-		return true
-	}
-	return false
-}
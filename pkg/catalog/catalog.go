@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dream-horizon-org/odin/app"
+	"github.com/dream-horizon-org/odin/pkg/util"
+	componentDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// defaultCatalogFiles are looked up in the odin config directory when no catalog file is given
+var defaultCatalogFiles = []string{"catalog.yaml", "catalog.yml", "catalog.json"}
+
+// DefaultPath returns the locally cached component catalog file if one exists
+func DefaultPath() string {
+	dirPath := path.Join(os.Getenv("HOME"), "."+app.App.Name)
+	for _, fileName := range defaultCatalogFiles {
+		filePath := path.Join(dirPath, fileName)
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath
+		}
+	}
+	return ""
+}
+
+// Load reads a component catalog file holding a list of components in JSON or YAML format
+func Load(filePath string) ([]*componentDto.Component, error) {
+	return util.ParseProtoListFile(filePath, func() *componentDto.Component {
+		return &componentDto.Component{}
+	})
+}
+
+// FindOperation finds the operation of the given component type and version in the catalog.
+// An empty version matches any version of the component type.
+func FindOperation(components []*componentDto.Component, componentType, componentVersion, operationName string) (*componentDto.Operation, error) {
+	for _, component := range components {
+		if component.GetComponentType() != componentType {
+			continue
+		}
+		if componentVersion != "" && component.GetComponentVersion() != componentVersion {
+			continue
+		}
+		for _, flavour := range component.GetFlavours() {
+			for _, operation := range flavour.GetOperations() {
+				if operation.GetName() == operationName {
+					return operation, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("operation %s not found for component type %s version %s in catalog", operationName, componentType, componentVersion)
+}
+
+// ApplyDefaults deep merges the operation defaults into the options, values in options take precedence
+func ApplyDefaults(defaults *structpb.Struct, options map[string]interface{}) map[string]interface{} {
+	return mergeMaps(defaults.AsMap(), options)
+}
+
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// Validate validates the options against the JSON schema of the operation and returns readable errors
+func Validate(schema *structpb.Struct, options map[string]interface{}) ([]string, error) {
+	if schema == nil || len(schema.GetFields()) == 0 {
+		return nil, nil
+	}
+	schemaJSON, err := schema.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read operation schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("operation.json", bytes.NewReader(schemaJSON)); err != nil {
+		return nil, fmt.Errorf("invalid operation schema: %w", err)
+	}
+	compiledSchema, err := compiler.Compile("operation.json")
+	if err != nil {
+		return nil, fmt.Errorf("invalid operation schema: %w", err)
+	}
+
+	// Round trip through JSON so that numbers and nested values have the types the validator expects
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	var instance interface{}
+	if err := json.Unmarshal(optionsJSON, &instance); err != nil {
+		return nil, err
+	}
+
+	err = compiledSchema.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+	violations := collectViolations(validationError)
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// collectViolations flattens the nested validation errors into one message per failing value
+func collectViolations(validationError *jsonschema.ValidationError) []string {
+	if len(validationError.Causes) == 0 {
+		location := strings.ReplaceAll(strings.TrimPrefix(validationError.InstanceLocation, "/"), "/", ".")
+		if location == "" {
+			location = "options"
+		}
+		return []string{fmt.Sprintf("%s: %s", location, validationError.Message)}
+	}
+	var violations []string
+	for _, cause := range validationError.Causes {
+		violations = append(violations, collectViolations(cause)...)
+	}
+	return violations
+}
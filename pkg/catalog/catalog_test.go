@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"testing"
+
+	componentDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(m map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestFindOperation(t *testing.T) {
+	redeploy := &componentDto.Operation{Name: proto.String("redeploy")}
+	components := []*componentDto.Component{
+		{
+			ComponentType:    proto.String("application"),
+			ComponentVersion: proto.String("1.0.0"),
+			Flavours: []*componentDto.Flavour{
+				{Name: proto.String("vm"), Operations: []*componentDto.Operation{redeploy}},
+			},
+		},
+	}
+
+	operation, err := FindOperation(components, "application", "1.0.0", "redeploy")
+	require.NoError(t, err)
+	assert.Same(t, redeploy, operation)
+
+	operation, err = FindOperation(components, "application", "", "redeploy")
+	require.NoError(t, err)
+	assert.Same(t, redeploy, operation)
+
+	_, err = FindOperation(components, "application", "2.0.0", "redeploy")
+	assert.Error(t, err)
+}
+
+func TestApplyDefaults(t *testing.T) {
+	defaults := mustStruct(map[string]interface{}{
+		"replicas": 2,
+		"image":    map[string]interface{}{"tag": "latest", "pullPolicy": "Always"},
+	})
+	merged := ApplyDefaults(defaults, map[string]interface{}{
+		"image": map[string]interface{}{"tag": "v2"},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"replicas": float64(2),
+		"image":    map[string]interface{}{"tag": "v2", "pullPolicy": "Always"},
+	}, merged)
+}
+
+func TestValidate(t *testing.T) {
+	schema := mustStruct(map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"replicas"},
+		"properties": map[string]interface{}{
+			"replicas": map[string]interface{}{"type": "integer", "minimum": 1},
+			"image": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"tag": map[string]interface{}{"type": "string"}},
+			},
+		},
+	})
+
+	violations, err := Validate(schema, map[string]interface{}{"replicas": float64(3)})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = Validate(schema, map[string]interface{}{
+		"replicas": float64(0),
+		"image":    map[string]interface{}{"tag": float64(1)},
+	})
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0], "image.tag: expected string")
+	assert.Contains(t, violations[1], "replicas: must be >= 1")
+
+	violations, err = Validate(nil, map[string]interface{}{"anything": true})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
@@ -2,128 +2,362 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path"
 	"strings"
 
 	"github.com/dream-horizon-org/odin/api/configuration"
 	"github.com/dream-horizon-org/odin/app"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/credential"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/mitchellh/mapstructure"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
 // fileViper is a dedicated viper instance for reading/writing ~/.odin/config.
 // It is intentionally decoupled from the global CLI viper to avoid persisting
-// runtime flags/env at the root level of the config file.
+// runtime flags/env at the root level of the config file. It reads the file alone:
+// ODIN_* environment variables are applied by activeProfile and applyOverrides.
 var fileViper = viper.New()
 
-func readConfigFile() {
+// readConfigFile reads the config file, returning viper.ConfigFileNotFoundError when it doesn't exist
+func readConfigFile() error {
 	fileViper.SetConfigName("config")
+	fileViper.SetConfigPermissions(0600)
 	fileViper.SetConfigType("toml")
 	fileViper.AddConfigPath("$HOME/." + app.App.Name)
-	fileViper.SetEnvPrefix("ODIN")
-	fileViper.SetEnvKeyReplacer(strings.NewReplacer(`.`, `_`))
-	fileViper.AutomaticEnv()
+	fileViper.SetDefault("profile", "default")
 
-	if err := fileViper.ReadInConfig(); err != nil {
+	return fileViper.ReadInConfig()
+}
+
+// requireConfigFile reads the config file, failing when it doesn't exist or can't be read
+func requireConfigFile() error {
+	found, err := readOptionalConfigFile()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return exitcode.Errorf(exitcode.Auth, "Not configured odin yet? Run `odin configure`")
+	}
+	return nil
+}
+
+// readOptionalConfigFile reads the config file when it exists
+func readOptionalConfigFile() (bool, error) {
+	if err := readConfigFile(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
 		if errors.As(err, &configFileNotFoundError) {
-			log.Fatal("Not configured odin yet? Run `odin configure`")
+			return false, nil
 		}
-		log.Fatal("Error while reading config file: ", err)
+		return false, exitcode.Errorf(exitcode.Validation, "Error while reading config file: %v", err)
 	}
+	return true, nil
 }
 
 func getConfigForProfile(profile string) (*configuration.Configuration, error) {
 	config := configuration.Configuration{}
 	if err := fileViper.UnmarshalKey(profile, &config); err != nil {
-		log.Fatal("Configuration can't be loaded: ", err)
+		return nil, exitcode.Errorf(exitcode.Validation, "Configuration can't be loaded: %v", err)
 	}
 	return &config, nil
 }
 
+// readConfig reads the active profile, overridden by the environment.
+// The config file is optional so that CI jobs can be configured from the environment alone.
 func readConfig() (*configuration.Configuration, error) {
-	readConfigFile()
-	profile := fileViper.GetString("profile")
-	return getConfigForProfile(profile)
+	fileFound, err := readOptionalConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	profile := activeProfile()
+	config, err := getConfigForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	token, found, err := environmentAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		config.AccessToken = token
+	} else if fileFound {
+		if err := resolveAccessToken(profile, config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyOverrides(config); err != nil {
+		return nil, err
+	}
+	return config, nil
 }
 
-// GetConfig returns the reference of viper config
-func GetConfig() *configuration.Configuration {
-	cfg, err := readConfig()
+// environmentAccessToken returns the access token given by ODIN_ACCESS_TOKEN or else ODIN_TOKEN_FILE.
+// The token file is read on every call so that rotated secrets are picked up.
+func environmentAccessToken() (string, bool, error) {
+	if token, ok := os.LookupEnv(constant.AccessTokenEnv); ok && token != "" {
+		return strings.TrimSpace(token), true, nil
+	}
+	tokenFile, ok := os.LookupEnv(constant.TokenFileEnv)
+	if !ok || tokenFile == "" {
+		return "", false, nil
+	}
+	content, err := os.ReadFile(tokenFile)
 	if err != nil {
-		log.Fatal("Error while reading config: ", err)
+		return "", false, fmt.Errorf("unable to read %s: %w", constant.TokenFileEnv, err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", false, fmt.Errorf("%s %s is empty", constant.TokenFileEnv, tokenFile)
 	}
-	return cfg
+	return token, true, nil
 }
 
-// WriteConfig writes the given config to the config file
-func WriteConfig(config *configuration.Configuration) {
-	activeProfile := viper.GetString("profile")
-	fileViper.Set("profile", activeProfile)
-	fileViper.Set(activeProfile, config)
-	if err := fileViper.WriteConfig(); err != nil {
-		log.Fatal("Unable to write configuration: ", err)
+// AccessTokenFromEnvironment checks if the access token is injected through the environment instead of the profile
+func AccessTokenFromEnvironment() bool {
+	return os.Getenv(constant.AccessTokenEnv) != "" || os.Getenv(constant.TokenFileEnv) != ""
+}
+
+// resolveAccessToken loads the access token from the credential store of the profile.
+// Plaintext tokens of profiles without a credential store are migrated to the default store.
+func resolveAccessToken(profile string, config *configuration.Configuration) error {
+	if config.CredentialStore == "" {
+		if config.AccessToken == "" {
+			return nil
+		}
+		log.Debugf("Migrating access token of profile [%s] out of the config file", profile)
+		return writeProfile(profile, config)
+	}
+
+	store, err := credential.New(config.CredentialStore)
+	if err != nil || store == nil {
+		return err
 	}
+	token, err := store.Get(profile)
+	if err != nil && !errors.Is(err, credential.ErrNotFound) {
+		return fmt.Errorf("unable to read access token from %s credential store: %w", config.CredentialStore, err)
+	}
+	config.AccessToken = token
+	return nil
 }
 
-// SetProfile sets the profile in the config file
-func SetProfile(profileName string) {
-	readConfigFile()
+// writeProfile writes the configuration of the profile to the config file,
+// keeping the access token in the credential store of the profile
+func writeProfile(profile string, config *configuration.Configuration) error {
+	fileConfig := *config
+	if fileConfig.CredentialStore == "" {
+		fileConfig.CredentialStore = credential.DefaultBackend()
+		config.CredentialStore = fileConfig.CredentialStore
+	}
 
-	config, err := getConfigForProfile(profileName)
+	store, err := credential.New(fileConfig.CredentialStore)
 	if err != nil {
-		log.Fatal("Error while reading config: ", err)
+		return err
+	}
+	if store != nil {
+		if fileConfig.AccessToken != "" {
+			if err := store.Set(profile, fileConfig.AccessToken); err != nil {
+				return fmt.Errorf("unable to save access token to %s credential store: %w", fileConfig.CredentialStore, err)
+			}
+		}
+		fileConfig.AccessToken = ""
+	}
+
+	var profileSettings map[string]interface{}
+	if err := mapstructure.Decode(fileConfig, &profileSettings); err != nil {
+		return err
+	}
+	settings := fileViper.AllSettings()
+	settings[strings.ToLower(profile)] = profileSettings
+	return rewriteConfig(settings)
+}
+
+// rewriteConfig writes the settings to the config file. viper merges values set at runtime into the values
+// read from the file, so a fresh instance is written to drop keys which were unset or cleared.
+func rewriteConfig(settings map[string]interface{}) error {
+	configFile := fileViper.ConfigFileUsed()
+	if configFile == "" {
+		configFile = path.Join(os.Getenv("HOME"), "."+app.App.Name, "config")
+	}
+	rewritten := viper.New()
+	rewritten.SetConfigFile(configFile)
+	rewritten.SetConfigType("toml")
+	rewritten.SetConfigPermissions(0600)
+	for key, value := range settings {
+		rewritten.Set(key, value)
+	}
+	if err := rewritten.WriteConfig(); err != nil {
+		return err
+	}
+	fileViper = rewritten
+	return nil
+}
+
+// GetConfig returns the configuration of the active profile, overridden by the environment and flags
+func GetConfig() (*configuration.Configuration, error) {
+	return readConfig()
+}
+
+// GetProfileConfig returns the configuration of the active profile as stored in the config file, without the
+// overrides of the environment and flags
+func GetProfileConfig() (*configuration.Configuration, error) {
+	if _, err := readOptionalConfigFile(); err != nil {
+		return nil, err
+	}
+	return getConfigForProfile(activeProfile())
+}
+
+// WriteConfig writes the given config to the active profile, which becomes the profile of the config file
+// unless ODIN_PROFILE or .odin.yaml selected it
+func WriteConfig(config *configuration.Configuration) error {
+	profile := activeProfile()
+	if config.CredentialStore == "" {
+		// Keep the credential store already chosen for the profile
+		existing, err := getConfigForProfile(profile)
+		if err == nil {
+			config.CredentialStore = existing.CredentialStore
+		}
+	}
+	if !profileFromEnvironment() {
+		fileViper.Set(profileKey, profile)
+	}
+	if err := writeProfile(profile, config); err != nil {
+		return fmt.Errorf("unable to write configuration: %w", err)
+	}
+	return nil
+}
+
+// SetProfile sets the profile in the config file
+func SetProfile(profileName string) error {
+	if err := requireConfigFile(); err != nil {
+		return err
 	}
-	if *config == (configuration.Configuration{}) {
-		log.Fatal("Configuration for profile [", profileName, "] not found!")
+	if !profileExists(profileName) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, profileName)
 	}
 
 	fileViper.Set("profile", profileName)
 	if err := fileViper.WriteConfig(); err != nil {
-		log.Fatal("Unable to write configuration: ", err)
+		return fmt.Errorf("unable to write configuration: %w", err)
 	}
+	return nil
 }
 
 // UpdateEnvName updates the EnvName in the configuration for the given profile
-func UpdateEnvName(envName string) {
-	readConfigFile()
-	profile := fileViper.GetString("profile")
+func UpdateEnvName(envName string) error {
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	profile := activeProfile()
 
 	// Retrieve the configuration for the specified profile
 	config, err := getConfigForProfile(profile)
 	if err != nil {
-		log.Fatal("Error while reading config: ", err)
+		return err
 	}
 
 	// Update the EnvName field
 	config.EnvName = envName
 
 	// Write the updated configuration back to the file
-	fileViper.Set(profile, config)
-	if err := fileViper.WriteConfig(); err != nil {
-		log.Fatal("Unable to write configuration: ", err)
+	if err := writeProfile(profile, config); err != nil {
+		return fmt.Errorf("unable to write configuration: %w", err)
 	}
 	log.Infof("EnvName updated to [%s] successfully in profile [%s]", envName, profile)
+	return nil
 }
 
-// GetActiveProfileEnvName returns the EnvName for the active profile
-func GetActiveProfileEnvName() string {
-	readConfigFile()
-	profile := fileViper.GetString("profile")
+// UpdateAccessToken updates the AccessToken in the configuration for the active profile
+func UpdateAccessToken(accessToken string) error {
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	profile := activeProfile()
+
+	config, err := getConfigForProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	config.AccessToken = accessToken
+
+	if err := writeProfile(profile, config); err != nil {
+		return fmt.Errorf("unable to write configuration: %w", err)
+	}
+	log.Infof("Access token updated successfully in profile [%s]", profile)
+	return nil
+}
+
+// ClearAccessToken removes the access token of the active profile from its credential store and the config file
+func ClearAccessToken() error {
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	profile := activeProfile()
+
 	config, err := getConfigForProfile(profile)
 	if err != nil {
-		log.Fatal("Error while reading config: ", err)
+		return err
+	}
+
+	if config.CredentialStore != "" {
+		store, err := credential.New(config.CredentialStore)
+		if err != nil {
+			return err
+		}
+		if store != nil {
+			if err := store.Delete(profile); err != nil && !errors.Is(err, credential.ErrNotFound) {
+				return fmt.Errorf("unable to remove access token from %s credential store: %w", config.CredentialStore, err)
+			}
+		}
+	}
+	config.AccessToken = ""
+
+	if err := writeProfile(profile, config); err != nil {
+		return fmt.Errorf("unable to write configuration: %w", err)
+	}
+	return nil
+}
+
+// GetActiveProfile returns the name of the active profile
+func GetActiveProfile() (string, error) {
+	if _, err := readOptionalConfigFile(); err != nil {
+		return "", err
+	}
+	return activeProfile(), nil
+}
+
+// GetActiveProfileEnvName returns the EnvName for the active profile
+func GetActiveProfileEnvName() (string, error) {
+	if _, err := readOptionalConfigFile(); err != nil {
+		return "", err
+	}
+	config, err := getConfigForProfile(activeProfile())
+	if err != nil {
+		return "", err
 	}
-	return config.EnvName
+	return config.EnvName, nil
 }
 
-// EnsureEnvPresent checks if default env is present in config else asks user for env via --env param
-func EnsureEnvPresent(inputEnv string) string {
+// EnsureEnvPresent returns the env given via --env, else the env of .odin.yaml, else the default env of the profile
+func EnsureEnvPresent(inputEnv string) (string, error) {
 	if inputEnv != "" {
-		return inputEnv
+		return inputEnv, nil
+	}
+	if env := GetProject().Env; env != "" {
+		return env, nil
+	}
+	env, err := GetActiveProfileEnvName()
+	if err != nil {
+		return "", err
 	}
-	env := GetActiveProfileEnvName()
 	if env == "" {
-		log.Fatal("Please provide the environment name using --env, or set the default environment using `odin set env <env-name>`")
+		return "", exitcode.Errorf(exitcode.Validation, "Please provide the environment name using --env, set env in %s, or set the default environment using `odin set env <env-name>`", ProjectFileName)
 	}
-	return env
+	return env, nil
 }
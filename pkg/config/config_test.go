@@ -0,0 +1,213 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/app"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/credential"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+const plaintextProfile = `profile = "default"
+
+[default]
+backend_address = "odin.example.com:443"
+org_id = 7
+access_token = "profile-token"
+credential_store = "plaintext"
+`
+
+// setupHome points HOME to a temporary directory, optionally holding a config file, and clears the odin environment
+func setupHome(t *testing.T, configContent string) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, env := range []string{constant.AccessTokenEnv, constant.TokenFileEnv, constant.BackendAddressEnv, constant.OrgIDEnv, "ODIN_PROFILE"} {
+		t.Setenv(env, "")
+	}
+	if configContent != "" {
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".odin"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".odin", "config"), []byte(configContent), 0600))
+	}
+	fileViper = viper.New()
+	currentProject = &Project{}
+	return home
+}
+
+func TestReadConfigWithoutConfigFile(t *testing.T) {
+	setupHome(t, "")
+	t.Setenv(constant.BackendAddressEnv, "odin.ci:443")
+	t.Setenv(constant.OrgIDEnv, "42")
+	t.Setenv(constant.AccessTokenEnv, "env-token")
+
+	config, err := readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "odin.ci:443", config.BackendAddress)
+	assert.Equal(t, int64(42), config.OrgId)
+	assert.Equal(t, "env-token", config.AccessToken)
+}
+
+func TestReadConfigTokenPrecedence(t *testing.T) {
+	tests := []struct {
+		name          string
+		accessToken   string
+		tokenFile     string
+		expectedToken string
+	}{
+		{name: "profile token", expectedToken: "profile-token"},
+		{name: "token file over profile", tokenFile: "file-token\n", expectedToken: "file-token"},
+		{name: "access token over token file", accessToken: "env-token", tokenFile: "file-token", expectedToken: "env-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := setupHome(t, plaintextProfile)
+			t.Setenv(constant.AccessTokenEnv, tt.accessToken)
+			if tt.tokenFile != "" {
+				tokenPath := filepath.Join(home, "token")
+				require.NoError(t, os.WriteFile(tokenPath, []byte(tt.tokenFile), 0600))
+				t.Setenv(constant.TokenFileEnv, tokenPath)
+			}
+
+			config, err := readConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedToken, config.AccessToken)
+			assert.Equal(t, "odin.example.com:443", config.BackendAddress)
+		})
+	}
+}
+
+func TestReadConfigReloadsTokenFile(t *testing.T) {
+	home := setupHome(t, "")
+	tokenPath := filepath.Join(home, "token")
+	t.Setenv(constant.TokenFileEnv, tokenPath)
+
+	require.NoError(t, os.WriteFile(tokenPath, []byte("first"), 0600))
+	config, err := readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "first", config.AccessToken)
+
+	require.NoError(t, os.WriteFile(tokenPath, []byte("rotated"), 0600))
+	config, err = readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", config.AccessToken)
+
+	require.NoError(t, os.Remove(tokenPath))
+	_, err = readConfig()
+	assert.ErrorContains(t, err, constant.TokenFileEnv)
+}
+
+func TestReadConfigInvalidOrgID(t *testing.T) {
+	setupHome(t, "")
+	t.Setenv(constant.OrgIDEnv, "acme")
+
+	_, err := readConfig()
+	assert.ErrorContains(t, err, constant.OrgIDEnv)
+}
+
+func TestReadConfigMigratesPlaintextToken(t *testing.T) {
+	keyring.MockInit()
+	home := setupHome(t, `profile = "default"
+
+[default]
+backend_address = "odin.example.com:443"
+access_token = "legacy-token"
+`)
+
+	config, err := readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", config.AccessToken)
+	assert.Equal(t, credential.Keyring, config.CredentialStore)
+
+	stored, err := keyring.Get(app.App.Name, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", stored)
+
+	content, err := os.ReadFile(filepath.Join(home, ".odin", "config"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "access_token")
+	assert.NotContains(t, string(content), "legacy-token")
+	assert.Contains(t, string(content), "credential_store = 'keyring'")
+
+	// The migrated token is read back from the store
+	fileViper = viper.New()
+	config, err = readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", config.AccessToken)
+}
+
+func TestWriteKeepsEnvironmentOutOfConfigFile(t *testing.T) {
+	home := setupHome(t, plaintextProfile+`
+[staging]
+backend_address = "odin.staging.example.com:443"
+credential_store = "plaintext"
+`)
+	t.Setenv("ODIN_PROFILE", "staging")
+	t.Setenv(constant.BackendAddressEnv, "odin.override:443")
+
+	require.NoError(t, UpdateEnvName("foo"))
+	require.NoError(t, UpdateEnvName("bar"))
+
+	fileViper = viper.New()
+	require.NoError(t, readConfigFile())
+	assert.Equal(t, "default", fileViper.GetString("profile"))
+	assert.Equal(t, "bar", fileViper.GetString("staging.envname"))
+	assert.Equal(t, "odin.staging.example.com:443", fileViper.GetString("staging.backend_address"))
+	content, err := os.ReadFile(filepath.Join(home, ".odin", "config"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "odin.override")
+}
+
+func TestWriteConfigToActiveProfile(t *testing.T) {
+	tests := []struct {
+		name            string
+		envProfile      string
+		projectProfile  string
+		expectedProfile string
+	}{
+		{name: "config file", expectedProfile: "default"},
+		{name: "environment", envProfile: "staging", expectedProfile: "default"},
+		{name: "project file", projectProfile: "staging", expectedProfile: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupHome(t, plaintextProfile)
+			t.Setenv("ODIN_PROFILE", tt.envProfile)
+			currentProject = &Project{Profile: tt.projectProfile}
+			keyring.MockInit()
+
+			require.NoError(t, readConfigFile())
+			target := activeProfile()
+			require.NoError(t, WriteConfig(&configuration.Configuration{BackendAddress: "odin.written:443", OrgId: 9}))
+
+			fileViper = viper.New()
+			require.NoError(t, readConfigFile())
+			assert.Equal(t, tt.expectedProfile, fileViper.GetString("profile"))
+			assert.Equal(t, "odin.written:443", fileViper.GetString(target+".backend_address"))
+			if target != "default" {
+				assert.Equal(t, "odin.example.com:443", fileViper.GetString("default.backend_address"))
+			}
+		})
+	}
+}
+
+func TestGetProfileConfigIgnoresOverrides(t *testing.T) {
+	setupHome(t, plaintextProfile+`timeout = "2m"
+retries = "5"
+envName = "dev"
+`)
+	t.Setenv(constant.BackendAddressEnv, "odin.override:443")
+
+	config, err := GetProfileConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "odin.example.com:443", config.BackendAddress)
+	assert.Equal(t, "2m", config.Timeout)
+	assert.Equal(t, "5", config.Retries)
+	assert.Equal(t, "dev", config.EnvName)
+}
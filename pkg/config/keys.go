@@ -0,0 +1,397 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/credential"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/proxy"
+	"github.com/dream-horizon-org/odin/pkg/tlsconfig"
+	"github.com/spf13/pflag"
+)
+
+const (
+	profileKey         = "profile"
+	accessTokenKey     = "access_token"
+	credentialStoreKey = "credential_store"
+	timeoutKey         = "timeout"
+	retriesKey         = "retries"
+	minTLSVersionKey   = "min_tls_version"
+	proxyURLKey        = "proxy_url"
+	redactedValue      = "REDACTED"
+)
+
+// Setting is a configuration value of the active profile and where it came from
+type Setting struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// flagBindings holds the flags overriding configuration keys, registered through BindFlag
+var flagBindings = map[string]*pflag.Flag{}
+
+// BindFlag registers a flag which overrides the configuration key when it's set on the command line
+func BindFlag(key string, flag *pflag.Flag) {
+	flagBindings[key] = flag
+}
+
+// changedFlag returns the flag bound to the key when it's set on the command line
+func changedFlag(key string) (*pflag.Flag, bool) {
+	flag, ok := flagBindings[key]
+	return flag, ok && flag.Changed
+}
+
+// activeProfile returns the profile selected by --profile, ODIN_PROFILE, .odin.yaml or the config file, in that order
+func activeProfile() string {
+	if flag, ok := changedFlag(profileKey); ok {
+		return flag.Value.String()
+	}
+	if profile := os.Getenv(envName(profileKey)); profile != "" {
+		return profile
+	}
+	if profile := GetProject().Profile; profile != "" {
+		return profile
+	}
+	return fileViper.GetString(profileKey)
+}
+
+// profileFromEnvironment checks if the active profile is selected by ODIN_PROFILE or .odin.yaml, which only apply
+// to the current run, rather than by --profile or the config file
+func profileFromEnvironment() bool {
+	if _, ok := changedFlag(profileKey); ok {
+		return false
+	}
+	return os.Getenv(envName(profileKey)) != "" || GetProject().Profile != ""
+}
+
+// Keys returns the configuration keys of a profile
+func Keys() []string {
+	configType := reflect.TypeOf(configuration.Configuration{})
+	keys := make([]string, 0, configType.NumField())
+	for i := 0; i < configType.NumField(); i++ {
+		keys = append(keys, keyName(configType.Field(i)))
+	}
+	return keys
+}
+
+func keyName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+}
+
+// envName returns the environment variable overriding the key, e.g. ODIN_BACKEND_ADDRESS
+func envName(key string) string {
+	return "ODIN_" + strings.ToUpper(key)
+}
+
+// lookupField returns the field of the configuration with the given key
+func lookupField(key string) (reflect.StructField, error) {
+	configType := reflect.TypeOf(configuration.Configuration{})
+	for i := 0; i < configType.NumField(); i++ {
+		if field := configType.Field(i); strings.EqualFold(keyName(field), key) {
+			return field, nil
+		}
+	}
+	return reflect.StructField{}, exitcode.Errorf(exitcode.Validation, "unknown key: %s, supported keys are %v", key, Keys())
+}
+
+// settableField returns the field of the key, rejecting keys which are managed by other commands
+func settableField(key string) (reflect.StructField, error) {
+	field, err := lookupField(key)
+	if err != nil {
+		return field, err
+	}
+	if keyName(field) == accessTokenKey {
+		return field, exitcode.Errorf(exitcode.Validation, "access_token is managed by `odin auth login` and `odin auth logout`")
+	}
+	return field, nil
+}
+
+// parseValue parses the raw value into the type of the field
+func parseValue(field reflect.StructField, raw string) (reflect.Value, error) {
+	raw = strings.TrimSpace(raw)
+	switch field.Type.Kind() {
+	case reflect.String:
+		if err := validateFormat(keyName(field), raw); err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(raw), nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return reflect.Value{}, exitcode.Errorf(exitcode.Validation, "invalid value %q for %s: expected true or false", raw, keyName(field))
+		}
+		return reflect.ValueOf(value), nil
+	case reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return reflect.Value{}, exitcode.Errorf(exitcode.Validation, "invalid value %q for %s: expected an integer", raw, keyName(field))
+		}
+		return reflect.ValueOf(value), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s of %s", field.Type, keyName(field))
+	}
+}
+
+// validateFormat checks the value of the string keys which hold a duration, a count, a TLS version or a URL
+func validateFormat(key, raw string) error {
+	var err error
+	switch key {
+	case timeoutKey:
+		_, err = parseTimeout(raw)
+	case retriesKey:
+		_, err = parseRetries(raw)
+	case minTLSVersionKey:
+		_, err = tlsconfig.ParseVersion(raw)
+	case proxyURLKey:
+		if raw != "" {
+			_, err = proxy.ParseURL(raw)
+		}
+	}
+	return err
+}
+
+func parseTimeout(raw string) (time.Duration, error) {
+	if raw == "" {
+		return constant.RequestTimeout, nil
+	}
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout < 0 {
+		return 0, exitcode.Errorf(exitcode.Validation, "invalid value %q for %s: expected a duration such as 30s or 2m", raw, timeoutKey)
+	}
+	return timeout, nil
+}
+
+func parseRetries(raw string) (int, error) {
+	if raw == "" {
+		return constant.MaxRetries, nil
+	}
+	retries, err := strconv.Atoi(raw)
+	if err != nil || retries < 0 {
+		return 0, exitcode.Errorf(exitcode.Validation, "invalid value %q for %s: expected a positive integer or 0", raw, retriesKey)
+	}
+	return retries, nil
+}
+
+// RequestTimeout returns the deadline of each request to the backend, 0 for none
+func RequestTimeout(config *configuration.Configuration) (time.Duration, error) {
+	return parseTimeout(strings.TrimSpace(config.Timeout))
+}
+
+// Retries returns the number of retries of a request failing with a transient error
+func Retries(config *configuration.Configuration) (int, error) {
+	return parseRetries(strings.TrimSpace(config.Retries))
+}
+
+// applyOverrides overrides the profile with ODIN_* environment variables and then with bound flags.
+// The access token and its credential store are resolved separately.
+func applyOverrides(config *configuration.Configuration) error {
+	configValue := reflect.ValueOf(config).Elem()
+	configType := configValue.Type()
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key := keyName(field)
+		if key == accessTokenKey || key == credentialStoreKey {
+			continue
+		}
+		if raw := os.Getenv(envName(key)); raw != "" {
+			value, err := parseValue(field, raw)
+			if err != nil {
+				return fmt.Errorf("%s: %w", envName(key), err)
+			}
+			configValue.Field(i).Set(value)
+		}
+		if flag, ok := changedFlag(key); ok {
+			value, err := parseValue(field, flag.Value.String())
+			if err != nil {
+				return fmt.Errorf("--%s: %w", flag.Name, err)
+			}
+			configValue.Field(i).Set(value)
+		}
+	}
+	return nil
+}
+
+// GetValue returns the value of the key stored in the profile, the active profile when empty
+func GetValue(profile, key string) (interface{}, error) {
+	field, err := settableField(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireConfigFile(); err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = activeProfile()
+	}
+	if !profileExists(profile) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+	config, err := getConfigForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(config).Elem().FieldByIndex(field.Index).Interface(), nil
+}
+
+// SetValue type checks the raw value against the key and writes it to the profile, the active profile when empty.
+// The profile is created when it doesn't exist.
+func SetValue(profile, key, raw string) error {
+	field, err := settableField(key)
+	if err != nil {
+		return err
+	}
+	value, err := parseValue(field, raw)
+	if err != nil {
+		return err
+	}
+	if keyName(field) == credentialStoreKey {
+		if _, err := credential.New(value.String()); err != nil {
+			return err
+		}
+	}
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	if profile == "" {
+		profile = activeProfile()
+	}
+	return updateProfile(profile, func(config *configuration.Configuration) {
+		reflect.ValueOf(config).Elem().FieldByIndex(field.Index).Set(value)
+	})
+}
+
+// UnsetValue resets the key of the profile, the active profile when empty, to its default
+func UnsetValue(profile, key string) error {
+	field, err := settableField(key)
+	if err != nil {
+		return err
+	}
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	if profile == "" {
+		profile = activeProfile()
+	}
+	if !profileExists(profile) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+	return updateProfile(profile, func(config *configuration.Configuration) {
+		fieldValue := reflect.ValueOf(config).Elem().FieldByIndex(field.Index)
+		fieldValue.Set(reflect.Zero(fieldValue.Type()))
+	})
+}
+
+// updateProfile applies the update to the profile, moving its access token when the credential store changes
+func updateProfile(profile string, update func(*configuration.Configuration)) error {
+	if err := validateProfileName(profile); err != nil {
+		return err
+	}
+	config, err := getConfigForProfile(profile)
+	if err != nil {
+		return err
+	}
+	if err := resolveAccessToken(profile, config); err != nil {
+		return err
+	}
+	previousStore := config.CredentialStore
+
+	update(config)
+	if err := writeProfile(profile, config); err != nil {
+		return err
+	}
+
+	if previousStore == "" || previousStore == config.CredentialStore {
+		return nil
+	}
+	store, err := credential.New(previousStore)
+	if err != nil || store == nil {
+		return err
+	}
+	if err := store.Delete(profile); err != nil && !errors.Is(err, credential.ErrNotFound) {
+		return fmt.Errorf("unable to remove access token from %s credential store: %w", previousStore, err)
+	}
+	return nil
+}
+
+// View returns every key of the active profile merged from the config file, ODIN_* environment variables and flags,
+// along with the source of each value
+func View() ([]Setting, error) {
+	fileFound, err := readOptionalConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	profile := activeProfile()
+	settings := []Setting{{Key: profileKey, Value: profile, Source: profileSource(fileFound)}}
+
+	merged, err := readConfig()
+	if err != nil {
+		return nil, err
+	}
+	section, _ := fileViper.Get(profile).(map[string]interface{})
+	mergedValue := reflect.ValueOf(merged).Elem()
+	for i := 0; i < mergedValue.NumField(); i++ {
+		key := keyName(mergedValue.Type().Field(i))
+		setting := Setting{Key: key, Value: mergedValue.Field(i).Interface(), Source: "default"}
+		if _, ok := section[strings.ToLower(key)]; ok {
+			setting.Source = "config file"
+		}
+
+		switch key {
+		case accessTokenKey:
+			setting.Source = accessTokenSource(merged, setting.Source)
+			if merged.AccessToken != "" {
+				setting.Value = redactedValue
+			}
+		case credentialStoreKey:
+		default:
+			if os.Getenv(envName(key)) != "" {
+				setting.Source = "env " + envName(key)
+			}
+			if flag, ok := changedFlag(key); ok {
+				setting.Source = "flag --" + flag.Name
+			}
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+func profileSource(fileFound bool) string {
+	if flag, ok := changedFlag(profileKey); ok {
+		return "flag --" + flag.Name
+	}
+	if os.Getenv(envName(profileKey)) != "" {
+		return "env " + envName(profileKey)
+	}
+	if project := GetProject(); project.Profile != "" {
+		return "project file " + project.Path()
+	}
+	if fileFound && fileViper.InConfig(profileKey) {
+		return "config file"
+	}
+	return "default"
+}
+
+func accessTokenSource(config *configuration.Configuration, fileSource string) string {
+	switch {
+	case os.Getenv(constant.AccessTokenEnv) != "":
+		return "env " + constant.AccessTokenEnv
+	case os.Getenv(constant.TokenFileEnv) != "":
+		return "env " + constant.TokenFileEnv
+	case config.AccessToken == "":
+		return "default"
+	case config.CredentialStore != "" && config.CredentialStore != credential.Plaintext:
+		return "credential store " + config.CredentialStore
+	default:
+		return fileSource
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/credential"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		value       string
+		expected    interface{}
+		errContains string
+	}{
+		{name: "string", key: "backend_address", value: "odin.test:443", expected: "odin.test:443"},
+		{name: "integer", key: "org_id", value: "12", expected: int64(12)},
+		{name: "boolean", key: "insecure", value: "true", expected: true},
+		{name: "key is case-insensitive", key: "ENVNAME", value: "dev", expected: "dev"},
+		{name: "invalid integer", key: "org_id", value: "acme", errContains: "expected an integer"},
+		{name: "invalid boolean", key: "plaintext", value: "maybe", errContains: "expected true or false"},
+		{name: "unknown key", key: "region", value: "us", errContains: "unknown key"},
+		{name: "managed key", key: "access_token", value: "token", errContains: "odin auth"},
+		{name: "unknown credential store", key: "credential_store", value: "vault", errContains: "unknown credential store"},
+		{name: "timeout", key: "timeout", value: "2m", expected: "2m"},
+		{name: "invalid timeout", key: "timeout", value: "soon", errContains: "expected a duration"},
+		{name: "retries", key: "retries", value: "0", expected: "0"},
+		{name: "minimum TLS version", key: "min_tls_version", value: "1.3", expected: "1.3"},
+		{name: "invalid minimum TLS version", key: "min_tls_version", value: "1.1", errContains: "expected 1.2 or 1.3"},
+		{name: "proxy URL", key: "proxy_url", value: "socks5://localhost:1080", expected: "socks5://localhost:1080"},
+		{name: "unsupported proxy scheme", key: "proxy_url", value: "ftp://proxy:21", errContains: "unsupported proxy scheme"},
+		{name: "negative retries", key: "retries", value: "-1", errContains: "expected a positive integer or 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupHome(t, plaintextProfile)
+
+			err := SetValue("", tt.key, tt.value)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			value, err := GetValue("", tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestUnsetValue(t *testing.T) {
+	home := setupHome(t, plaintextProfile)
+
+	require.NoError(t, SetValue("", "insecure", "true"))
+	require.NoError(t, UnsetValue("", "insecure"))
+	value, err := GetValue("", "insecure")
+	require.NoError(t, err)
+	assert.Equal(t, false, value)
+
+	content, err := os.ReadFile(filepath.Join(home, ".odin", "config"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "insecure")
+	assert.ErrorIs(t, UnsetValue("staging", "insecure"), ErrProfileNotFound)
+}
+
+func TestSetCredentialStoreMovesToken(t *testing.T) {
+	home := setupHome(t, plaintextProfile)
+	t.Setenv(credential.PassphraseEnv, "secret")
+
+	require.NoError(t, SetValue("", "credential_store", credential.EncryptedFile))
+
+	content, err := os.ReadFile(filepath.Join(home, ".odin", "config"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "profile-token")
+	config, err := readConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "profile-token", config.AccessToken)
+}
+
+func TestRequestSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  configuration.Configuration
+		timeout time.Duration
+		retries int
+	}{
+		{name: "defaults", timeout: constant.RequestTimeout, retries: constant.MaxRetries},
+		{name: "profile", config: configuration.Configuration{Timeout: "1m30s", Retries: "2"}, timeout: 90 * time.Second, retries: 2},
+		{name: "disabled", config: configuration.Configuration{Timeout: "0", Retries: "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := RequestTimeout(&tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.timeout, timeout)
+			retries, err := Retries(&tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.retries, retries)
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	setupHome(t, plaintextProfile)
+	t.Setenv(constant.OrgIDEnv, "9")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("insecure", false, "")
+	require.NoError(t, flags.Parse([]string{"--insecure"}))
+	BindFlag("insecure", flags.Lookup("insecure"))
+	t.Cleanup(func() { delete(flagBindings, "insecure") })
+
+	settings, err := View()
+	require.NoError(t, err)
+	byKey := map[string]Setting{}
+	for _, setting := range settings {
+		byKey[setting.Key] = setting
+	}
+	assert.Equal(t, Setting{Key: "profile", Value: "default", Source: "config file"}, byKey["profile"])
+	assert.Equal(t, Setting{Key: "backend_address", Value: "odin.example.com:443", Source: "config file"}, byKey["backend_address"])
+	assert.Equal(t, Setting{Key: "org_id", Value: int64(9), Source: "env ODIN_ORG_ID"}, byKey["org_id"])
+	assert.Equal(t, Setting{Key: "insecure", Value: true, Source: "flag --insecure"}, byKey["insecure"])
+	assert.Equal(t, Setting{Key: "access_token", Value: redactedValue, Source: "config file"}, byKey["access_token"])
+	assert.Equal(t, Setting{Key: "envName", Value: "", Source: "default"}, byKey["envName"])
+}
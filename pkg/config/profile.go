@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/credential"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+)
+
+// ErrProfileNotFound is returned when the profile doesn't exist in the config file
+var ErrProfileNotFound = exitcode.New(exitcode.NotFound, errors.New("profile not found"))
+
+// ListProfiles returns the names of the configured profiles and the active profile, which flags, the environment
+// and .odin.yaml select over the profile of the config file
+func ListProfiles() ([]string, string, error) {
+	if err := requireConfigFile(); err != nil {
+		return nil, "", err
+	}
+	var profiles []string
+	for key, value := range fileViper.AllSettings() {
+		if _, ok := value.(map[string]interface{}); ok {
+			profiles = append(profiles, key)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, activeProfile(), nil
+}
+
+// GetProfile returns the configuration of the profile including its access token
+func GetProfile(name string) (*configuration.Configuration, error) {
+	if err := requireConfigFile(); err != nil {
+		return nil, err
+	}
+	if !profileExists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	config, err := getConfigForProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveAccessToken(name, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// CopyProfile copies the configuration and access token of the source profile to a new profile
+func CopyProfile(source, destination string) error {
+	// profile names are case-insensitive as viper lowercases keys
+	destination = strings.ToLower(destination)
+	if err := validateProfileName(destination); err != nil {
+		return err
+	}
+	config, err := GetProfile(source)
+	if err != nil {
+		return err
+	}
+	if profileExists(destination) {
+		return exitcode.Errorf(exitcode.Conflict, "profile %s already exists", destination)
+	}
+	return writeProfile(destination, config)
+}
+
+// RenameProfile renames the profile, moving its access token and keeping it active if it was
+func RenameProfile(source, destination string) error {
+	if err := CopyProfile(source, destination); err != nil {
+		return err
+	}
+	if strings.EqualFold(fileViper.GetString("profile"), source) {
+		fileViper.Set("profile", strings.ToLower(destination))
+	}
+	return removeProfile(source)
+}
+
+// DeleteProfile deletes the profile and its access token. The active profile can't be deleted.
+func DeleteProfile(name string) error {
+	if err := requireConfigFile(); err != nil {
+		return err
+	}
+	if !profileExists(name) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	if strings.EqualFold(fileViper.GetString("profile"), name) {
+		return exitcode.Errorf(exitcode.Conflict, "profile %s is active, switch to another profile with `odin set profile` first", name)
+	}
+	return removeProfile(name)
+}
+
+// validateProfileName checks that the name can be used as a section of the config file
+func validateProfileName(name string) error {
+	if name == "" || strings.EqualFold(name, profileKey) || strings.Contains(name, ".") {
+		return exitcode.Errorf(exitcode.Validation, "invalid profile name: %q", name)
+	}
+	return nil
+}
+
+// profileExists checks if the profile has a section in the config file
+func profileExists(name string) bool {
+	_, ok := fileViper.Get(name).(map[string]interface{})
+	return ok && name != ""
+}
+
+// removeProfile removes the token of the profile from its credential store and the profile from the config file
+func removeProfile(name string) error {
+	config, err := getConfigForProfile(name)
+	if err != nil {
+		return err
+	}
+	if config.CredentialStore != "" {
+		store, err := credential.New(config.CredentialStore)
+		if err != nil {
+			return err
+		}
+		if store != nil {
+			if err := store.Delete(name); err != nil && !errors.Is(err, credential.ErrNotFound) {
+				return fmt.Errorf("unable to remove access token from %s credential store: %w", config.CredentialStore, err)
+			}
+		}
+	}
+
+	settings := fileViper.AllSettings()
+	delete(settings, strings.ToLower(name))
+	return rewriteConfig(settings)
+}
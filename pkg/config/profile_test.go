@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoProfiles = plaintextProfile + `
+[staging]
+backend_address = "odin.staging.example.com:443"
+org_id = 8
+access_token = "staging-token"
+credential_store = "plaintext"
+`
+
+func TestListProfiles(t *testing.T) {
+	setupHome(t, twoProfiles)
+
+	profiles, active, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "staging"}, profiles)
+	assert.Equal(t, "default", active)
+
+	t.Setenv("ODIN_PROFILE", "staging")
+	_, active, err = ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "staging", active)
+}
+
+func TestCopyProfile(t *testing.T) {
+	setupHome(t, twoProfiles)
+
+	require.NoError(t, CopyProfile("staging", "LoadTest"))
+	copied, err := GetProfile("loadtest")
+	require.NoError(t, err)
+	assert.Equal(t, "odin.staging.example.com:443", copied.BackendAddress)
+	assert.Equal(t, "staging-token", copied.AccessToken)
+
+	assert.ErrorContains(t, CopyProfile("staging", "default"), "already exists")
+	assert.ErrorIs(t, CopyProfile("production", "other"), ErrProfileNotFound)
+	assert.ErrorContains(t, CopyProfile("staging", "profile"), "invalid profile name")
+}
+
+func TestRenameProfile(t *testing.T) {
+	setupHome(t, twoProfiles)
+
+	require.NoError(t, RenameProfile("default", "production"))
+	profiles, active, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"production", "staging"}, profiles)
+	assert.Equal(t, "production", active)
+
+	renamed, err := GetProfile("production")
+	require.NoError(t, err)
+	assert.Equal(t, "profile-token", renamed.AccessToken)
+	_, err = GetProfile("default")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestDeleteProfile(t *testing.T) {
+	setupHome(t, twoProfiles)
+
+	assert.ErrorContains(t, DeleteProfile("default"), "is active")
+	assert.ErrorIs(t, DeleteProfile("production"), ErrProfileNotFound)
+
+	require.NoError(t, DeleteProfile("staging"))
+	profiles, active, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, profiles)
+	assert.Equal(t, "default", active)
+}
+
+func TestSetProfile(t *testing.T) {
+	setupHome(t, "")
+	assert.Equal(t, exitcode.Auth, exitcode.FromError(SetProfile("staging")))
+
+	setupHome(t, twoProfiles)
+	assert.Equal(t, exitcode.NotFound, exitcode.FromError(SetProfile("production")))
+	require.NoError(t, SetProfile("staging"))
+	_, active, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "staging", active)
+}
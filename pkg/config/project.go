@@ -0,0 +1,122 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the name of the project-local context file, discovered by walking up from the working directory
+const ProjectFileName = ".odin.yaml"
+
+// Project holds the defaults of a service repository. They take precedence over the profile but not over flags.
+type Project struct {
+	Env          string `yaml:"env"`
+	Service      string `yaml:"service"`
+	Definition   string `yaml:"definition"`
+	Provisioning string `yaml:"provisioning"`
+	Profile      string `yaml:"profile"`
+
+	// path of the file the project was read from, empty when there is none
+	path string
+}
+
+// currentProject caches the project of the working directory
+var currentProject *Project
+
+// LoadProject reads the project of the working directory. It runs before every command, so that an invalid
+// .odin.yaml fails the command instead of being ignored.
+func LoadProject() error {
+	currentProject = &Project{}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		log.Debugf("Unable to get the working directory: %v", err)
+		return nil
+	}
+	project, err := loadProject(workingDir)
+	if err != nil {
+		return err
+	}
+	if project.path != "" {
+		log.Debugf("Using defaults from %s", project.path)
+	}
+	currentProject = project
+	return nil
+}
+
+// GetProject returns the project of the working directory, or an empty project when there is no .odin.yaml or it
+// can't be read
+func GetProject() *Project {
+	if currentProject == nil {
+		if err := LoadProject(); err != nil {
+			log.Debugf("Ignoring %s: %v", ProjectFileName, err)
+		}
+	}
+	return currentProject
+}
+
+// Path returns the path of the project file, empty when there is none
+func (p *Project) Path() string {
+	return p.path
+}
+
+// DefinitionFile returns the path of the service definition file relative to the working directory
+func (p *Project) DefinitionFile() string {
+	return p.resolve(p.Definition)
+}
+
+// ProvisioningFile returns the path of the provisioning file relative to the working directory
+func (p *Project) ProvisioningFile() string {
+	return p.resolve(p.Provisioning)
+}
+
+// resolve resolves paths relative to the directory of the project file
+func (p *Project) resolve(file string) string {
+	if file == "" || filepath.IsAbs(file) || p.path == "" {
+		return file
+	}
+	return filepath.Join(filepath.Dir(p.path), file)
+}
+
+// findProjectFile walks up from the directory until it finds a project file
+func findProjectFile(dir string) (string, bool) {
+	for {
+		candidate := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// loadProject reads the project file found from the directory, returning an empty project when there is none
+func loadProject(dir string) (*Project, error) {
+	projectFile, found := findProjectFile(dir)
+	if !found {
+		return &Project{}, nil
+	}
+	content, err := os.ReadFile(projectFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", projectFile, err)
+	}
+
+	project := &Project{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(project); err != nil && !errors.Is(err, io.EOF) {
+		return nil, exitcode.New(exitcode.Validation, fmt.Errorf("unable to parse %s: %w", projectFile, err))
+	}
+	project.path = projectFile
+	return project, nil
+}
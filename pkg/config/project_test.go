@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProject(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), []byte(`env: staging
+service: orders
+definition: odin/definition.yaml
+provisioning: /etc/odin/provisioning.yaml
+profile: stage
+`), 0600))
+	workingDir := filepath.Join(repo, "src", "handlers")
+	require.NoError(t, os.MkdirAll(workingDir, 0700))
+
+	project, err := loadProject(workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, ProjectFileName), project.Path())
+	assert.Equal(t, "staging", project.Env)
+	assert.Equal(t, "orders", project.Service)
+	assert.Equal(t, "stage", project.Profile)
+	assert.Equal(t, filepath.Join(repo, "odin", "definition.yaml"), project.DefinitionFile())
+	assert.Equal(t, "/etc/odin/provisioning.yaml", project.ProvisioningFile())
+}
+
+func TestLoadProjectErrors(t *testing.T) {
+	project, err := loadProject(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, &Project{}, project)
+
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), []byte("enviroment: staging\n"), 0600))
+	_, err = loadProject(repo)
+	assert.ErrorContains(t, err, "field enviroment not found")
+}
+
+func TestProjectPrecedence(t *testing.T) {
+	setupHome(t, plaintextProfile+"envName = \"profile-env\"\n\n[stage]\nbackend_address = \"odin.stage:443\"\n")
+	currentProject = &Project{Env: "project-env", Profile: "stage"}
+
+	assertEnv(t, "flag-env", "flag-env")
+	assertEnv(t, "project-env", "")
+	profile, err := GetActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "stage", profile)
+
+	t.Setenv("ODIN_PROFILE", "default")
+	profile, err = GetActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "default", profile)
+
+	currentProject = &Project{}
+	assertEnv(t, "profile-env", "")
+}
+
+func TestEnsureEnvPresentMissing(t *testing.T) {
+	setupHome(t, plaintextProfile)
+
+	_, err := EnsureEnvPresent("")
+	assert.ErrorContains(t, err, "Please provide the environment name")
+	assert.Equal(t, exitcode.Validation, exitcode.FromError(err))
+}
+
+func assertEnv(t *testing.T, expected, inputEnv string) {
+	t.Helper()
+	env, err := EnsureEnvPresent(inputEnv)
+	require.NoError(t, err)
+	assert.Equal(t, expected, env)
+}
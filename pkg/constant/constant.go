@@ -8,11 +8,19 @@ type TraceID string
 // VerboseEnabled is the type for verboseEnabledKey
 type VerboseEnabled string
 
+// OutputFormat is the type for OutputFormatKey
+type OutputFormat string
+
+// LogDrain is the type for LogDrainKey
+type LogDrain string
+
 const (
 	// TEXT type output format
 	TEXT = "text"
 	// JSON type output format
 	JSON = "json"
+	// YAML type output format
+	YAML = "yaml"
 	// SpinnerColor Defines color of spinner
 	SpinnerColor = "fgHiBlue"
 
@@ -31,23 +39,50 @@ const (
 	// VerboseEnabledKey is the key used to store verbose value in context
 	VerboseEnabledKey VerboseEnabled = "verbose"
 
+	// OutputFormatKey is the key used to store the output format in context
+	OutputFormatKey OutputFormat = "output"
+
+	// LogDrainKey is the key used to store the log drain timings in context
+	LogDrainKey LogDrain = "log-drain"
+
 	// VerboseFlag is the key used to store verbose value
 	VerboseFlag string = "verbose"
 
 	// LogLevelKey is the key used to set log level
 	LogLevelKey = "ODIN_LOG_LEVEL"
 
+	// AccessTokenEnv is the environment variable holding an access token, which takes precedence over the profile
+	AccessTokenEnv = "ODIN_ACCESS_TOKEN"
+
+	// TokenFileEnv is the environment variable holding the path of a file with the access token
+	TokenFileEnv = "ODIN_TOKEN_FILE"
+
+	// BackendAddressEnv is the environment variable overriding the backend address of the profile
+	BackendAddressEnv = "ODIN_BACKEND_ADDRESS"
+
+	// OrgIDEnv is the environment variable overriding the organisation id of the profile
+	OrgIDEnv = "ODIN_ORG_ID"
+
 	// ConsentMessageTemplate is the template for the consent message
 	ConsentMessageTemplate = "\nYou are executing the above command on a restricted environment. Are you sure? Enter \033[1m%s\033[0m to continue:"
 
 	// InitiatingRetryMessage is the message shown when retrying
 	InitiatingRetryMessage = "Unable to reach Odin backend."
 
-	// MaxRetries is the maximum number of retries
+	// MaxRetries is the default number of retries of a request failing with a transient error
 	MaxRetries = 5
 
-	// Delay is the delay between retries
-	Delay = 10 * time.Second
+	// RetryInitialBackoff is the wait before the first retry, doubled on every retry
+	RetryInitialBackoff = time.Second
+
+	// RetryMaxBackoff is the longest wait between retries
+	RetryMaxBackoff = 10 * time.Second
+
+	// RetryJitter is the fraction of the wait between retries which is random
+	RetryJitter = 0.2
+
+	// RequestTimeout is the default deadline of a unary call to the backend
+	RequestTimeout = 30 * time.Second
 
 	// MaxRetriesReached is the message shown when max retries are reached
 	MaxRetriesReached = "Max retries reached. Exiting...\nPlease check:\n- Your internet connection: \n- VPN connected properly"
@@ -55,6 +90,9 @@ const (
 	// RetryingMessage is the message shown when retrying
 	RetryingMessage = "Retrying ... (%d/%d)"
 
+	// TokenExpiryWarningWindow is how long before the access token expiry a warning is shown
+	TokenExpiryWarningWindow = 10 * time.Minute
+
 	// CheckingAdditionalLogsMessage is the message shown when checking for additional logs
 	CheckingAdditionalLogsMessage = "Execution completed. Checking for additional logs..."
 
@@ -0,0 +1,55 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/dream-horizon-org/odin/app"
+)
+
+const (
+	// Keyring stores tokens in the OS keyring (Secret Service, macOS Keychain, Windows Credential Manager)
+	Keyring = "keyring"
+	// EncryptedFile stores tokens in a passphrase encrypted file
+	EncryptedFile = "file"
+	// Plaintext stores tokens in the config file
+	Plaintext = "plaintext"
+)
+
+// Backends lists the supported credential store backends
+var Backends = []string{Keyring, EncryptedFile, Plaintext}
+
+// ErrNotFound is returned when no token is stored for the profile
+var ErrNotFound = errors.New("credential not found")
+
+// Store persists access tokens per profile
+type Store interface {
+	Get(profile string) (string, error)
+	Set(profile, token string) error
+	Delete(profile string) error
+}
+
+// New returns the store of the given backend.
+// The plaintext backend is owned by the config file and has no store, so nil is returned for it.
+func New(backend string) (Store, error) {
+	switch backend {
+	case Keyring:
+		return &keyringStore{}, nil
+	case EncryptedFile:
+		return &encryptedFileStore{path: path.Join(os.Getenv("HOME"), "."+app.App.Name, "credentials")}, nil
+	case Plaintext:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown credential store: %s, supported stores are %v", backend, Backends)
+	}
+}
+
+// DefaultBackend returns the keyring when it is usable on this machine, else plaintext
+func DefaultBackend() string {
+	if keyringAvailable() {
+		return Keyring
+	}
+	return Plaintext
+}
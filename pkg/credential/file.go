@@ -0,0 +1,172 @@
+package credential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dream-horizon-org/odin/internal/ui"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	// PassphraseEnv is the environment variable holding the passphrase of the encrypted credentials file
+	PassphraseEnv = "ODIN_CREDENTIALS_PASSPHRASE"
+
+	saltLength = 16
+	keyLength  = 32
+	scryptN    = 1 << 15
+	scryptR    = 8
+	scryptP    = 1
+)
+
+// encryptedFile is the on-disk format of the encrypted credentials file
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// encryptedFileStore stores the tokens of all profiles in one AES-GCM encrypted file,
+// keyed by a scrypt derivation of the user's passphrase
+type encryptedFileStore struct {
+	path       string
+	passphrase string
+}
+
+// Get returns the token of the profile
+func (f *encryptedFileStore) Get(profile string) (string, error) {
+	tokens, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[profile]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return token, nil
+}
+
+// Set stores the token of the profile
+func (f *encryptedFileStore) Set(profile, token string) error {
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	tokens[profile] = token
+	return f.write(tokens)
+}
+
+// Delete removes the token of the profile
+func (f *encryptedFileStore) Delete(profile string) error {
+	tokens, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[profile]; !ok {
+		return nil
+	}
+	delete(tokens, profile)
+	return f.write(tokens)
+}
+
+func (f *encryptedFileStore) getPassphrase() (string, error) {
+	if f.passphrase != "" {
+		return f.passphrase, nil
+	}
+	if value, ok := os.LookupEnv(PassphraseEnv); ok && value != "" {
+		f.passphrase = value
+		return value, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("passphrase of the credentials file is required, set %s", PassphraseEnv)
+	}
+	inputHandler := ui.Input{}
+	value, err := inputHandler.AskSecret("Passphrase of the odin credentials file:")
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", errors.New("passphrase cannot be empty")
+	}
+	f.passphrase = value
+	return value, nil
+}
+
+func (f *encryptedFileStore) read() (map[string]string, error) {
+	content, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("credentials file is corrupted: %w", err)
+	}
+	gcm, err := f.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt credentials file, wrong passphrase?")
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("credentials file is corrupted: %w", err)
+	}
+	return tokens, nil
+}
+
+func (f *encryptedFileStore) write(tokens map[string]string) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	file := encryptedFile{Salt: make([]byte, saltLength)}
+	if _, err := io.ReadFull(rand.Reader, file.Salt); err != nil {
+		return err
+	}
+	gcm, err := f.cipher(file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, file.Nonce); err != nil {
+		return err
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	content, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, content, 0600)
+}
+
+func (f *encryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	passphrase, err := f.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
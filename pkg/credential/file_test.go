@@ -0,0 +1,46 @@
+package credential
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedFileStore(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "credentials")
+	store := &encryptedFileStore{path: filePath, passphrase: "correct horse"}
+
+	_, err := store.Get("default")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Set("default", "token-1"))
+	require.NoError(t, store.Set("staging", "token-2"))
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "token-1", "tokens must not be stored in plaintext")
+
+	reopened := &encryptedFileStore{path: filePath, passphrase: "correct horse"}
+	token, err := reopened.Get("staging")
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	require.NoError(t, reopened.Delete("staging"))
+	_, err = reopened.Get("staging")
+	assert.ErrorIs(t, err, ErrNotFound)
+	token, err = reopened.Get("default")
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	wrongPassphrase := &encryptedFileStore{path: filePath, passphrase: "battery staple"}
+	_, err = wrongPassphrase.Get("default")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong passphrase")
+}
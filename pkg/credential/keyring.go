@@ -0,0 +1,42 @@
+package credential
+
+import (
+	"errors"
+
+	"github.com/dream-horizon-org/odin/app"
+	"github.com/zalando/go-keyring"
+)
+
+// keyringProbeUser is looked up to check whether a keyring is reachable
+const keyringProbeUser = "__odin_probe__"
+
+// keyringStore stores tokens in the OS keyring under the odin service, one entry per profile
+type keyringStore struct{}
+
+// Get returns the token of the profile
+func (k *keyringStore) Get(profile string) (string, error) {
+	token, err := keyring.Get(app.App.Name, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return token, err
+}
+
+// Set stores the token of the profile
+func (k *keyringStore) Set(profile, token string) error {
+	return keyring.Set(app.App.Name, profile, token)
+}
+
+// Delete removes the token of the profile
+func (k *keyringStore) Delete(profile string) error {
+	err := keyring.Delete(app.App.Name, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func keyringAvailable() bool {
+	_, err := keyring.Get(app.App.Name, keyringProbeUser)
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
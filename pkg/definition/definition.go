@@ -0,0 +1,156 @@
+package definition
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dream-horizon-org/odin/pkg/util"
+	serviceDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+)
+
+// Violation describes a problem in a service definition or provisioning file
+type Violation struct {
+	File    string
+	Path    string
+	Message string
+}
+
+// String formats the violation as file: path: message
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s: %s", v.File, v.Path, v.Message)
+}
+
+// Load parses the service definition and provisioning files
+func Load(definitionFile, provisioningFile string) (*serviceDto.ServiceDefinition, *serviceDto.ProvisioningConfig, error) {
+	var definition serviceDto.ServiceDefinition
+	if err := util.ParseProtoFile(definitionFile, &definition); err != nil {
+		return nil, nil, fmt.Errorf("error while parsing definition file:\n%w", err)
+	}
+
+	componentProvisioningConfigs, err := util.ParseProtoListFile(provisioningFile, func() *serviceDto.ComponentProvisioningConfig {
+		return &serviceDto.ComponentProvisioningConfig{}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while parsing provisioning file:\n%w", err)
+	}
+	return &definition, &serviceDto.ProvisioningConfig{
+		ComponentProvisioningConfig: componentProvisioningConfigs,
+	}, nil
+}
+
+// Validate checks the service definition and provisioning config offline and returns every violation found
+func Validate(definitionFile string, definition *serviceDto.ServiceDefinition, provisioningFile string, provisioning *serviceDto.ProvisioningConfig) []Violation {
+	var violations []Violation
+	addDefinitionViolation := func(path, format string, args ...interface{}) {
+		violations = append(violations, Violation{File: definitionFile, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	addProvisioningViolation := func(path, format string, args ...interface{}) {
+		violations = append(violations, Violation{File: provisioningFile, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if definition.GetName() == "" {
+		addDefinitionViolation("$.name", "name is required")
+	}
+	if definition.GetVersion() == "" {
+		addDefinitionViolation("$.version", "version is required")
+	}
+
+	components := map[string]*serviceDto.ComponentDefinition{}
+	for i, component := range definition.GetComponents() {
+		path := fmt.Sprintf("$.components[%d]", i)
+		if component.GetName() == "" {
+			addDefinitionViolation(path+".name", "name is required")
+		} else if _, ok := components[component.GetName()]; ok {
+			addDefinitionViolation(path+".name", "duplicate component name %q", component.GetName())
+		} else {
+			components[component.GetName()] = component
+		}
+		if component.GetVersion() == "" {
+			addDefinitionViolation(path+".version", "version is required")
+		}
+	}
+
+	for i, component := range definition.GetComponents() {
+		for j, dependency := range component.GetDependsOn() {
+			if _, ok := components[dependency]; !ok {
+				addDefinitionViolation(fmt.Sprintf("$.components[%d].depends_on[%d]", i, j), "unknown component %q", dependency)
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(definition.GetComponents(), components) {
+		addDefinitionViolation(fmt.Sprintf("$.components[%d].depends_on", cycle.index), "dependency cycle %s", strings.Join(cycle.path, " -> "))
+	}
+
+	provisioned := map[string]bool{}
+	for i, config := range provisioning.GetComponentProvisioningConfig() {
+		path := fmt.Sprintf("$[%d].component_name", i)
+		name := config.GetComponentName()
+		switch {
+		case name == "":
+			addProvisioningViolation(path, "component_name is required")
+		case provisioned[name]:
+			addProvisioningViolation(path, "duplicate provisioning config for component %q", name)
+		case components[name] == nil:
+			addProvisioningViolation(path, "component %q is not defined in the service definition", name)
+		}
+		provisioned[name] = true
+	}
+
+	return violations
+}
+
+type cycle struct {
+	index int
+	path  []string
+}
+
+// findCycles walks the depends_on graph and reports each dependency cycle once
+func findCycles(definitions []*serviceDto.ComponentDefinition, components map[string]*serviceDto.ComponentDefinition) []cycle {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	indexes := map[string]int{}
+	for i, component := range definitions {
+		if _, ok := indexes[component.GetName()]; !ok {
+			indexes[component.GetName()] = i
+		}
+	}
+
+	state := map[string]int{}
+	var stack []string
+	var cycles []cycle
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dependency := range components[name].GetDependsOn() {
+			if _, ok := components[dependency]; !ok {
+				continue
+			}
+			switch state[dependency] {
+			case unvisited:
+				visit(dependency)
+			case visiting:
+				start := 0
+				for stack[start] != dependency {
+					start++
+				}
+				path := append(append([]string{}, stack[start:]...), dependency)
+				cycles = append(cycles, cycle{index: indexes[dependency], path: path})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, component := range definitions {
+		if _, ok := components[component.GetName()]; ok && state[component.GetName()] == unvisited {
+			visit(component.GetName())
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,88 @@
+package definition
+
+import (
+	"testing"
+
+	serviceDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func component(name, version string, dependsOn ...string) *serviceDto.ComponentDefinition {
+	return &serviceDto.ComponentDefinition{Name: name, Version: version, DependsOn: dependsOn}
+}
+
+func provisioning(names ...string) *serviceDto.ProvisioningConfig {
+	config := &serviceDto.ProvisioningConfig{}
+	for _, name := range names {
+		config.ComponentProvisioningConfig = append(config.ComponentProvisioningConfig, &serviceDto.ComponentProvisioningConfig{ComponentName: name})
+	}
+	return config
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		definition   *serviceDto.ServiceDefinition
+		provisioning *serviceDto.ProvisioningConfig
+		want         []string
+	}{
+		{
+			name: "valid definition",
+			definition: &serviceDto.ServiceDefinition{Name: "orders", Version: "1.0.0", Components: []*serviceDto.ComponentDefinition{
+				component("app", "1.0.0", "db"),
+				component("db", "1.0.0"),
+			}},
+			provisioning: provisioning("app", "db"),
+		},
+		{
+			name:         "missing names and versions",
+			definition:   &serviceDto.ServiceDefinition{Components: []*serviceDto.ComponentDefinition{component("", "")}},
+			provisioning: provisioning(""),
+			want: []string{
+				"def.yaml: $.name: name is required",
+				"def.yaml: $.version: version is required",
+				"def.yaml: $.components[0].name: name is required",
+				"def.yaml: $.components[0].version: version is required",
+				"prov.yaml: $[0].component_name: component_name is required",
+			},
+		},
+		{
+			name: "duplicate and unknown components",
+			definition: &serviceDto.ServiceDefinition{Name: "orders", Version: "1.0.0", Components: []*serviceDto.ComponentDefinition{
+				component("app", "1.0.0", "cache"),
+				component("app", "1.0.0"),
+			}},
+			provisioning: provisioning("app", "app", "db"),
+			want: []string{
+				`def.yaml: $.components[1].name: duplicate component name "app"`,
+				`def.yaml: $.components[0].depends_on[0]: unknown component "cache"`,
+				`prov.yaml: $[1].component_name: duplicate provisioning config for component "app"`,
+				`prov.yaml: $[2].component_name: component "db" is not defined in the service definition`,
+			},
+		},
+		{
+			name: "dependency cycle",
+			definition: &serviceDto.ServiceDefinition{Name: "orders", Version: "1.0.0", Components: []*serviceDto.ComponentDefinition{
+				component("app", "1.0.0", "db"),
+				component("db", "1.0.0", "cache"),
+				component("cache", "1.0.0", "app"),
+				component("worker", "1.0.0", "worker"),
+			}},
+			provisioning: provisioning("app"),
+			want: []string{
+				"def.yaml: $.components[0].depends_on: dependency cycle app -> db -> cache -> app",
+				"def.yaml: $.components[3].depends_on: dependency cycle worker -> worker",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, violation := range Validate("def.yaml", tt.definition, "prov.yaml", tt.provisioning) {
+				got = append(got, violation.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -45,10 +45,10 @@ func CreateDirIfNotExist(path string) error {
 	return nil
 }
 
-// CreateFileIfNotExist : create file if it doesn't exist
-func CreateFileIfNotExist(path string) error {
+// CreateFileIfNotExist : create file with the given permission if it doesn't exist
+func CreateFileIfNotExist(path string, permission os.FileMode) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		file, err := os.Create(path)
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, permission)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,115 @@
+package envstatus
+
+import (
+	"strings"
+	"time"
+
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	"golang.org/x/exp/slices"
+)
+
+// Event is a change of the status of an environment, one of its services or one of their components
+type Event struct {
+	Time           time.Time `json:"time"`
+	Env            string    `json:"env"`
+	Service        string    `json:"service,omitempty"`
+	Component      string    `json:"component,omitempty"`
+	PreviousStatus string    `json:"previousStatus,omitempty"`
+	Status         string    `json:"status"`
+}
+
+// Key identifies the environment, service or component of the event
+func (e Event) Key() string {
+	return Key(e.Service, e.Component)
+}
+
+// Key identifies a service or a component of a service, the environment itself has an empty key
+func Key(service, component string) string {
+	if component == "" {
+		return service
+	}
+	return service + "/" + component
+}
+
+// Terminal statuses of environments, services, components and the actions run on them
+const (
+	Successful = "SUCCESSFUL"
+	Failed     = "FAILED"
+	Active     = "ACTIVE"
+	Deployed   = "DEPLOYED"
+	Undeployed = "UNDEPLOYED"
+	Deleted    = "DELETED"
+)
+
+var terminalStatuses = []string{Successful, Failed, Active, Deployed, Undeployed, Deleted}
+
+// Terminal reports whether the status is final. An empty or unknown status, e.g. QUEUED, is still in progress.
+func Terminal(status string) bool {
+	return slices.Contains(terminalStatuses, strings.ToUpper(status))
+}
+
+// Settled reports whether every service and component of the environment reached a terminal status
+func Settled(response *environment.StatusEnvironmentResponse) bool {
+	if response == nil {
+		return false
+	}
+	if len(response.GetServicesStatus()) == 0 {
+		return Terminal(response.GetEnvStatus())
+	}
+	for _, svc := range response.GetServicesStatus() {
+		if !Terminal(svc.GetServiceStatus()) {
+			return false
+		}
+		for _, component := range svc.GetComponentStatus() {
+			if !Terminal(component.GetComponentStatus()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Changes returns an event for every status which differs between the previous and the current response.
+// Every status is reported when there is no previous response.
+func Changes(previous, current *environment.StatusEnvironmentResponse, now time.Time) []Event {
+	previousStatuses := statuses(previous)
+	var events []Event
+	add := func(service, component, status string) {
+		previousStatus, found := previousStatuses[Key(service, component)]
+		if found && previousStatus == status {
+			return
+		}
+		events = append(events, Event{
+			Time:           now,
+			Env:            current.GetEnvName(),
+			Service:        service,
+			Component:      component,
+			PreviousStatus: previousStatus,
+			Status:         status,
+		})
+	}
+
+	add("", "", current.GetEnvStatus())
+	for _, svc := range current.GetServicesStatus() {
+		add(svc.GetServiceName(), "", svc.GetServiceStatus())
+		for _, component := range svc.GetComponentStatus() {
+			add(svc.GetServiceName(), component.GetComponentName(), component.GetComponentStatus())
+		}
+	}
+	return events
+}
+
+func statuses(response *environment.StatusEnvironmentResponse) map[string]string {
+	result := map[string]string{}
+	if response == nil {
+		return result
+	}
+	result[Key("", "")] = response.GetEnvStatus()
+	for _, svc := range response.GetServicesStatus() {
+		result[Key(svc.GetServiceName(), "")] = svc.GetServiceStatus()
+		for _, component := range svc.GetComponentStatus() {
+			result[Key(svc.GetServiceName(), component.GetComponentName())] = component.GetComponentStatus()
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package envstatus
+
+import (
+	"testing"
+	"time"
+
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func statusResponse(envStatus, serviceStatus string, componentStatuses ...string) *environment.StatusEnvironmentResponse {
+	var components []*environment.StatusEnvComponentStatus
+	for i, componentStatus := range componentStatuses {
+		components = append(components, &environment.StatusEnvComponentStatus{
+			ComponentName:   []string{"app", "db"}[i],
+			ComponentStatus: componentStatus,
+		})
+	}
+	return &environment.StatusEnvironmentResponse{
+		EnvName:   "staging",
+		EnvStatus: envStatus,
+		ServicesStatus: []*environment.DeployedServiceStatus{
+			{ServiceName: "orders", ServiceStatus: serviceStatus, ComponentStatus: components},
+		},
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected bool
+	}{
+		{status: "DEPLOYED", expected: true},
+		{status: "failed", expected: true},
+		{status: "SUCCESSFUL", expected: true},
+		{status: "ACTIVE", expected: true},
+		{status: "DEPLOYING"},
+		{status: "IN_PROGRESS"},
+		{status: "QUEUED"},
+		{status: "CREATED"},
+		{status: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Terminal(tt.status))
+		})
+	}
+}
+
+func TestSettled(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *environment.StatusEnvironmentResponse
+		expected bool
+	}{
+		{name: "no response"},
+		{name: "service deploying", response: statusResponse("ACTIVE", "DEPLOYING", "DEPLOYED")},
+		{name: "component deploying", response: statusResponse("ACTIVE", "DEPLOYED", "DEPLOYED", "DEPLOYING")},
+		{name: "all terminal", response: statusResponse("ACTIVE", "DEPLOYED", "DEPLOYED", "FAILED"), expected: true},
+		{name: "environment without services", response: &environment.StatusEnvironmentResponse{EnvStatus: "ACTIVE"}, expected: true},
+		{name: "environment creating", response: &environment.StatusEnvironmentResponse{EnvStatus: "CREATING"}},
+		{name: "environment without status", response: &environment.StatusEnvironmentResponse{}},
+		{name: "service queued", response: statusResponse("ACTIVE", "QUEUED", "DEPLOYED")},
+		{name: "component without status", response: statusResponse("ACTIVE", "DEPLOYED", "")},
+		{name: "environment with unknown status", response: &environment.StatusEnvironmentResponse{EnvStatus: "CREATED"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Settled(tt.response))
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	initial := statusResponse("ACTIVE", "DEPLOYING", "DEPLOYING")
+	assert.Equal(t, []Event{
+		{Time: now, Env: "staging", Status: "ACTIVE"},
+		{Time: now, Env: "staging", Service: "orders", Status: "DEPLOYING"},
+		{Time: now, Env: "staging", Service: "orders", Component: "app", Status: "DEPLOYING"},
+	}, Changes(nil, initial, now))
+
+	assert.Empty(t, Changes(initial, statusResponse("ACTIVE", "DEPLOYING", "DEPLOYING"), now))
+
+	updated := statusResponse("ACTIVE", "DEPLOYING", "DEPLOYED", "DEPLOYING")
+	events := Changes(initial, updated, now)
+	assert.Equal(t, []Event{
+		{Time: now, Env: "staging", Service: "orders", Component: "app", PreviousStatus: "DEPLOYING", Status: "DEPLOYED"},
+		{Time: now, Env: "staging", Service: "orders", Component: "db", Status: "DEPLOYING"},
+	}, events)
+	assert.Equal(t, "orders/app", events[0].Key())
+}
@@ -0,0 +1,113 @@
+// Package exitcode defines the exit codes of odin and maps errors onto them.
+//
+//	0  Success            the command succeeded
+//	1  Error              any other error
+//	2  Validation         invalid flags, arguments, files or configuration
+//	3  Auth               not logged in, expired token or permission denied
+//	4  NotFound           the environment, service, component or profile does not exist
+//	5  Conflict           the resource already exists or is being changed by another action
+//	6  Unavailable        the Odin backend could not be reached or timed out
+//	7  DeploymentFailed   the deployment or operation reached the FAILED state
+//	8  Aborted            the user aborted the command at a prompt
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of odin
+const (
+	Success          = 0
+	Error            = 1
+	Validation       = 2
+	Auth             = 3
+	NotFound         = 4
+	Conflict         = 5
+	Unavailable      = 6
+	DeploymentFailed = 7
+	Aborted          = 8
+)
+
+// grpcCodes maps gRPC status codes onto exit codes, other codes exit with Error
+var grpcCodes = map[codes.Code]int{
+	codes.InvalidArgument:    Validation,
+	codes.FailedPrecondition: Validation,
+	codes.OutOfRange:         Validation,
+	codes.Unauthenticated:    Auth,
+	codes.PermissionDenied:   Auth,
+	codes.NotFound:           NotFound,
+	codes.AlreadyExists:      Conflict,
+	codes.Aborted:            Conflict,
+	codes.Unavailable:        Unavailable,
+	codes.DeadlineExceeded:   Unavailable,
+}
+
+// ExitError is an error with the code odin exits with
+type ExitError struct {
+	Code    int
+	message string
+	err     error
+}
+
+// Error returns the message of the error
+func (e *ExitError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("exit code %d", e.Code)
+}
+
+// Unwrap returns the cause of the error
+func (e *ExitError) Unwrap() error {
+	return e.err
+}
+
+// New returns an error exiting with the code
+func New(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, err: err}
+}
+
+// Errorf returns an error with the formatted message exiting with the code
+func Errorf(code int, format string, args ...interface{}) error {
+	return New(code, fmt.Errorf(format, args...))
+}
+
+// Wrap prefixes the message of the error, using the message of the gRPC status for gRPC errors, and keeps its exit code
+func Wrap(err error, prefix string) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	var exitError *ExitError
+	if st, ok := status.FromError(err); ok && !errors.As(err, &exitError) {
+		message = st.Message()
+	}
+	return &ExitError{Code: FromError(err), message: prefix + message, err: err}
+}
+
+// FromError returns the exit code for the error. Codes set with New take precedence over gRPC status codes.
+func FromError(err error) int {
+	if err == nil {
+		return Success
+	}
+	var exitError *ExitError
+	if errors.As(err, &exitError) {
+		return exitError.Code
+	}
+	if st, ok := status.FromError(err); ok {
+		if code, found := grpcCodes[st.Code()]; found {
+			return code
+		}
+	}
+	return Error
+}
@@ -0,0 +1,52 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "no error", expected: Success},
+		{name: "plain error", err: errors.New("boom"), expected: Error},
+		{name: "exit error", err: Errorf(Aborted, "aborted"), expected: Aborted},
+		{name: "wrapped exit error", err: fmt.Errorf("deploy: %w", New(DeploymentFailed, errors.New("FAILED"))), expected: DeploymentFailed},
+		{name: "invalid argument", err: status.Error(codes.InvalidArgument, "bad definition"), expected: Validation},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "expired"), expected: Auth},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "denied"), expected: Auth},
+		{name: "not found", err: status.Error(codes.NotFound, "no env"), expected: NotFound},
+		{name: "already exists", err: status.Error(codes.AlreadyExists, "env exists"), expected: Conflict},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), expected: Unavailable},
+		{name: "deadline exceeded", err: status.Error(codes.DeadlineExceeded, "slow"), expected: Unavailable},
+		{name: "wrapped grpc error", err: fmt.Errorf("list: %w", status.Error(codes.NotFound, "no env")), expected: NotFound},
+		{name: "internal", err: status.Error(codes.Internal, "oops"), expected: Error},
+		{name: "canceled", err: status.Error(codes.Canceled, "context canceled"), expected: Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FromError(tt.err))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	assert.NoError(t, Wrap(nil, "Failed: "))
+
+	err := Wrap(status.Error(codes.NotFound, "environment staging not found"), "Failed to describe environment: ")
+	assert.EqualError(t, err, "Failed to describe environment: environment staging not found")
+	assert.Equal(t, NotFound, FromError(err))
+
+	err = Wrap(Errorf(DeploymentFailed, "service orders DEPLOY FAILED"), "Failed to deploy service: ")
+	assert.EqualError(t, err, "Failed to deploy service: service orders DEPLOY FAILED")
+	assert.Equal(t, DeploymentFailed, FromError(err))
+}
@@ -0,0 +1,247 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathNode is a parsed piece of a JSONPath template
+type jsonPathNode struct {
+	text     string
+	path     string
+	isPath   bool
+	children []jsonPathNode
+}
+
+// evaluateJSONPath evaluates a kubectl style JSONPath template such as '{.environments[*].name}'.
+// It supports fields, indexes, [*] and .* wildcards, {range}...{end} and quoted literals such as {"\n"}.
+// Missing fields produce no output, as protojson omits empty fields.
+func evaluateJSONPath(template string, data interface{}) (string, error) {
+	nodes, rest, err := parseJSONPath(template, false)
+	if err != nil {
+		return "", err
+	}
+	if rest != "" {
+		return "", fmt.Errorf("invalid jsonpath %q: unexpected {end}", template)
+	}
+	var builder strings.Builder
+	if err := writeJSONPath(&builder, nodes, data, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// parseJSONPath parses the template until its end, or until {end} when inside a range
+func parseJSONPath(template string, inRange bool) ([]jsonPathNode, string, error) {
+	var nodes []jsonPathNode
+	for template != "" {
+		start := strings.Index(template, "{")
+		if start < 0 {
+			nodes = append(nodes, jsonPathNode{text: template})
+			return nodes, "", nil
+		}
+		if start > 0 {
+			nodes = append(nodes, jsonPathNode{text: template[:start]})
+		}
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			return nil, "", fmt.Errorf("invalid jsonpath %q: unclosed {", template)
+		}
+		expression := strings.TrimSpace(template[start+1 : start+end])
+		template = template[start+end+1:]
+
+		switch {
+		case expression == "end":
+			if !inRange {
+				return nil, "{end}", nil
+			}
+			return nodes, template, nil
+		case strings.HasPrefix(expression, "range "):
+			children, rest, err := parseJSONPath(template, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: strings.TrimSpace(strings.TrimPrefix(expression, "range ")), isPath: true, children: children})
+			template = rest
+			continue
+		case strings.HasPrefix(expression, `"`):
+			literal, err := strconv.Unquote(expression)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid jsonpath literal %s: %w", expression, err)
+			}
+			nodes = append(nodes, jsonPathNode{text: literal})
+		default:
+			nodes = append(nodes, jsonPathNode{path: expression, isPath: true})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("invalid jsonpath: {range} without {end}")
+	}
+	return nodes, "", nil
+}
+
+func writeJSONPath(builder *strings.Builder, nodes []jsonPathNode, root, current interface{}) error {
+	for _, node := range nodes {
+		if !node.isPath {
+			builder.WriteString(node.text)
+			continue
+		}
+		values, err := selectJSONPath(node.path, root, current)
+		if err != nil {
+			return err
+		}
+		if node.children != nil {
+			for _, value := range values {
+				if err := writeJSONPath(builder, node.children, root, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		formatted := make([]string, 0, len(values))
+		for _, value := range values {
+			text, err := formatJSONPathValue(value)
+			if err != nil {
+				return err
+			}
+			formatted = append(formatted, text)
+		}
+		builder.WriteString(strings.Join(formatted, " "))
+	}
+	return nil
+}
+
+// selectJSONPath returns the values matched by the path, starting from the root for $ and the current value otherwise
+func selectJSONPath(path string, root, current interface{}) ([]interface{}, error) {
+	values := []interface{}{current}
+	switch {
+	case strings.HasPrefix(path, "$"):
+		values, path = []interface{}{root}, path[1:]
+	case strings.HasPrefix(path, "@"):
+		path = path[1:]
+	}
+
+	for path != "" && path != "." {
+		var step func(interface{}) []interface{}
+		switch {
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			field := path[:end]
+			path = path[end:]
+			if field == "" {
+				return nil, fmt.Errorf("invalid jsonpath: empty field name")
+			}
+			step = func(value interface{}) []interface{} {
+				if field == "*" {
+					return wildcard(value)
+				}
+				if object, ok := value.(map[string]interface{}); ok {
+					if child, ok := object[field]; ok {
+						return []interface{}{child}
+					}
+				}
+				return nil
+			}
+		case strings.HasPrefix(path, "["):
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid jsonpath: unclosed [")
+			}
+			subscript := strings.TrimSpace(path[1:end])
+			path = path[end+1:]
+			var err error
+			if step, err = subscriptStep(subscript); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("invalid jsonpath: unexpected %q", path)
+		}
+
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, step(value)...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func subscriptStep(subscript string) (func(interface{}) []interface{}, error) {
+	if subscript == "*" {
+		return wildcard, nil
+	}
+	if name, err := strconv.Unquote(strings.ReplaceAll(subscript, "'", `"`)); err == nil {
+		return func(value interface{}) []interface{} {
+			if object, ok := value.(map[string]interface{}); ok {
+				if child, ok := object[name]; ok {
+					return []interface{}{child}
+				}
+			}
+			return nil
+		}, nil
+	}
+	index, err := strconv.Atoi(subscript)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath subscript [%s]: expected an index, * or a quoted name", subscript)
+	}
+	return func(value interface{}) []interface{} {
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		position := index
+		if position < 0 {
+			position += len(list)
+		}
+		if position < 0 || position >= len(list) {
+			return nil
+		}
+		return []interface{}{list[position]}
+	}, nil
+}
+
+// wildcard returns the items of a list, or the values of an object ordered by key
+func wildcard(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case []interface{}:
+		return typed
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, typed[key])
+		}
+		return values
+	}
+	return nil
+}
+
+// formatJSONPathValue prints scalars as plain text and objects and lists as JSON
+func formatJSONPathValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
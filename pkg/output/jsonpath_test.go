@@ -0,0 +1,57 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateJSONPath(t *testing.T) {
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "environments": [
+    {"name": "staging", "state": "ACTIVE", "replicas": 2, "labels": {"team": "orders", "tier": "web"}},
+    {"name": "loadtest", "state": "DELETING", "replicas": 1.5}
+  ]
+}`), &data))
+
+	tests := []struct {
+		name        string
+		template    string
+		expected    string
+		errContains string
+	}{
+		{name: "wildcard", template: "{.environments[*].name}", expected: "staging loadtest"},
+		{name: "index", template: "{.environments[0].state}", expected: "ACTIVE"},
+		{name: "negative index", template: "{$.environments[-1].name}", expected: "loadtest"},
+		{name: "numbers", template: "{.environments[*].replicas}", expected: "2 1.5"},
+		{name: "object wildcard", template: "{.environments[0].labels.*}", expected: "orders web"},
+		{name: "quoted field", template: "{.environments[0].labels['team']}", expected: "orders"},
+		{name: "object as json", template: "{.environments[0].labels}", expected: `{"team":"orders","tier":"web"}`},
+		{name: "missing field", template: "{.environments[*].owner}", expected: ""},
+		{name: "text around", template: "envs: {.environments[1].name}!", expected: "envs: loadtest!"},
+		{
+			name:     "range",
+			template: `{range .environments[*]}{.name}{"\t"}{.state}{"\n"}{end}`,
+			expected: "staging\tACTIVE\nloadtest\tDELETING\n",
+		},
+		{name: "unclosed brace", template: "{.environments", errContains: "unclosed {"},
+		{name: "range without end", template: "{range .environments[*]}{.name}", errContains: "without {end}"},
+		{name: "end without range", template: "{.environments}{end}", errContains: "unexpected {end}"},
+		{name: "invalid subscript", template: "{.environments[a:b]}", errContains: "invalid jsonpath subscript"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := evaluateJSONPath(tt.template, data)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
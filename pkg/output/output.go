@@ -0,0 +1,238 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// JSONPath prints the values selected by a JSONPath template, e.g. jsonpath='{.environments[*].name}'
+	JSONPath = "jsonpath"
+	// GoTemplate executes a go template with the sprig functions, e.g. go-template='{{.environment.name}}'
+	GoTemplate = "go-template"
+	// TemplateFile executes the go template read from a file, e.g. template-file=path
+	TemplateFile = "template-file"
+)
+
+// Formats lists the output formats supported by every command
+var Formats = []string{constant.TEXT, Wide, constant.JSON, constant.YAML, JSONPath + "=...", GoTemplate + "=...", TemplateFile + "=...", CustomColumns + "=..."}
+
+// Print writes the value to stdout in the given format, calling text to print the text and wide formats.
+// It returns an error when the format is unknown or the value can't be rendered.
+func Print(format string, value interface{}, text func()) error {
+	if format == constant.TEXT || format == Wide {
+		text()
+		return nil
+	}
+	output, err := Render(format, value)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// PrintItem writes one item of a stream to stdout: a single line of JSON, a YAML document or the rendered template
+func PrintItem(format string, value interface{}) error {
+	switch format {
+	case constant.JSON:
+		data, err := toJSON(value, false)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case constant.YAML:
+		document, err := Render(format, value)
+		if err != nil {
+			return err
+		}
+		fmt.Print("---\n" + document)
+	default:
+		output, err := Render(format, value)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+	}
+	return nil
+}
+
+// Render renders the value as indented JSON, YAML, a JSONPath or a go template. Proto messages are rendered with
+// protojson, so that field names and timestamps match the API, other values with encoding/json.
+func Render(format string, value interface{}) (string, error) {
+	name, argument, _ := strings.Cut(format, "=")
+	if err := ValidateRenderFormat(format); err != nil {
+		return "", err
+	}
+	data, err := toJSON(value, true)
+	if err != nil {
+		return "", err
+	}
+
+	switch name {
+	case constant.JSON:
+		return string(data) + "\n", nil
+	case constant.YAML:
+		return jsonToYAML(data)
+	}
+
+	// JSONPath and templates see the same fields as the json output
+	generic, err := toGeneric(value)
+	if err != nil {
+		return "", err
+	}
+	var output string
+	switch name {
+	case JSONPath:
+		output, err = evaluateJSONPath(argument, generic)
+	case GoTemplate:
+		output, err = executeTemplate(argument, generic)
+	case TemplateFile:
+		var content []byte
+		if content, err = os.ReadFile(argument); err != nil {
+			return "", fmt.Errorf("unable to read template file: %w", err)
+		}
+		output, err = executeTemplate(string(content), generic)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	return output, nil
+}
+
+// ValidateFormat checks that the format is supported, for commands which validate it before any request
+func ValidateFormat(format string) error {
+	name, argument, hasArgument := strings.Cut(format, "=")
+	switch name {
+	case constant.TEXT, Wide, constant.JSON, constant.YAML:
+		if !hasArgument {
+			return nil
+		}
+	case JSONPath, GoTemplate, TemplateFile, CustomColumns:
+		if argument == "" {
+			return exitcode.Errorf(exitcode.Validation, "output format %s requires a value, e.g. %s=...", name, name)
+		}
+		return nil
+	}
+	return unknownFormatError(format)
+}
+
+// ValidateRenderFormat checks that the format is one Render supports, i.e. not a table format
+func ValidateRenderFormat(format string) error {
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	switch name, _, _ := strings.Cut(format, "="); name {
+	case constant.TEXT, Wide, CustomColumns:
+		return exitcode.Errorf(exitcode.Validation, "output format %s is not supported by this command", name)
+	}
+	return nil
+}
+
+// executeTemplate executes the go template with the sprig functions
+func executeTemplate(text string, data interface{}) (string, error) {
+	parsed, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+	var buffer bytes.Buffer
+	if err := parsed.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("unable to execute template: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+func unknownFormatError(format string) error {
+	return exitcode.Errorf(exitcode.Validation, "unknown output format: %s, supported formats are %s", format, strings.Join(Formats, ", "))
+}
+
+// toGeneric converts the value to the generic form of its json output
+func toGeneric(value interface{}) (interface{}, error) {
+	data, err := toJSON(value, false)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+func toJSON(value interface{}, indent bool) ([]byte, error) {
+	var data []byte
+	var err error
+	if message, ok := value.(proto.Message); ok {
+		data, err = protojson.Marshal(message)
+	} else {
+		data, err = json.Marshal(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to JSON: %w", err)
+	}
+
+	// protojson randomly varies its whitespace, so the output is always reformatted to keep it stable
+	var formatted bytes.Buffer
+	if indent {
+		err = json.Indent(&formatted, data, "", "  ")
+	} else {
+		err = json.Compact(&formatted, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to JSON: %w", err)
+	}
+	return formatted.Bytes(), nil
+}
+
+// jsonToYAML converts JSON to block style YAML, keeping the order of the fields and quoting strings only when needed
+func jsonToYAML(data []byte) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return "", fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	var toBlockStyle func(node *yaml.Node)
+	toBlockStyle = func(node *yaml.Node) {
+		node.Style = 0
+		for _, child := range node.Content {
+			toBlockStyle(child)
+		}
+	}
+	toBlockStyle(&document)
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return "", fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	return buffer.String(), nil
+}
+
+// ProgressFormat returns the output format of a command streaming the progress of an action, which prints text or
+// one JSON event per line
+func ProgressFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+	if format != constant.TEXT && format != constant.JSON {
+		return "", exitcode.Errorf(exitcode.Validation, "output format %s is not supported by this command, supported formats are %s and %s", format, constant.TEXT, constant.JSON)
+	}
+	return format, nil
+}
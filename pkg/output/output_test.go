@@ -0,0 +1,161 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	dto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+	environment "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/environment/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRender(t *testing.T) {
+	name, status := "staging", "ACTIVE"
+	response := &environment.DescribeEnvironmentResponse{
+		Environment: &dto.Environment{
+			Name:      &name,
+			Status:    &status,
+			CreatedAt: timestamppb.New(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)),
+		},
+	}
+	tests := []struct {
+		name     string
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:   "proto as json",
+			format: constant.JSON,
+			value:  response,
+			expected: `{
+  "environment": {
+    "createdAt": "2024-05-01T10:00:00Z",
+    "name": "staging",
+    "status": "ACTIVE"
+  }
+}
+`,
+		},
+		{
+			name:   "proto as yaml keeps field order",
+			format: constant.YAML,
+			value:  response,
+			expected: `environment:
+  createdAt: "2024-05-01T10:00:00Z"
+  name: staging
+  status: ACTIVE
+`,
+		},
+		{
+			name:   "struct as yaml",
+			format: constant.YAML,
+			value: struct {
+				Key    string   `json:"key"`
+				Values []string `json:"values"`
+			}{Key: "profile", Values: []string{"a", "b"}},
+			expected: "key: profile\nvalues:\n  - a\n  - b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := Render(tt.format, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestRenderSelectors(t *testing.T) {
+	response := &environment.ListEnvironmentResponse{
+		Environments: []*dto.EnvironmentSummary{
+			{Name: "staging", State: "ACTIVE"},
+			{Name: "loadtest", State: "DELETING"},
+		},
+	}
+	templateFile := filepath.Join(t.TempDir(), "envs.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{{range .environments}}{{.name | upper}}{{"\n"}}{{end}}`), 0600))
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "jsonpath", format: "jsonpath={.environments[*].name}", expected: "staging loadtest\n"},
+		{name: "go template with sprig", format: `go-template={{range .environments}}{{.state | lower}} {{end}}`, expected: "active deleting \n"},
+		{name: "template file", format: "template-file=" + templateFile, expected: "STAGING\nLOADTEST\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := Render(tt.format, response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	tests := []struct {
+		format      string
+		errContains string
+	}{
+		{format: constant.TEXT},
+		{format: constant.YAML},
+		{format: "jsonpath={.name}"},
+		{format: "jsonpath=", errContains: "requires a value"},
+		{format: "go-template", errContains: "requires a value"},
+		{format: "json=x", errContains: "unknown output format"},
+		{format: Wide},
+		{format: "custom-columns=", errContains: "requires a value"},
+		{format: "xml", errContains: "unknown output format: xml, supported formats are text, wide, json, yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := ValidateFormat(tt.format)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+
+	_, err := Render("template-file=missing.tmpl", struct{}{})
+	assert.ErrorContains(t, err, "unable to read template file")
+}
+
+func TestValidateRenderFormat(t *testing.T) {
+	tests := []struct {
+		format      string
+		errContains string
+	}{
+		{format: constant.JSON},
+		{format: constant.YAML},
+		{format: "go-template={{.name}}"},
+		{format: constant.TEXT, errContains: "not supported by this command"},
+		{format: Wide, errContains: "not supported by this command"},
+		{format: "custom-columns=NAME:.name", errContains: "not supported by this command"},
+		{format: "xml", errContains: "unknown output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := ValidateRenderFormat(tt.format)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+			assert.Equal(t, exitcode.Validation, exitcode.FromError(err))
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dream-horizon-org/odin/pkg/constant"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/dream-horizon-org/odin/pkg/table"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Wide prints the table with additional columns
+	Wide = "wide"
+	// CustomColumns prints the table with the given columns, e.g. custom-columns=NAME:.name,OWNER:.createdBy
+	CustomColumns = "custom-columns"
+
+	// noneValue is printed for custom columns without a value
+	noneValue = "<none>"
+)
+
+// Column is a column of a table of rows of type T
+type Column[T any] struct {
+	Header string
+	// Wide columns are only printed with -o wide
+	Wide  bool
+	Value func(row T) interface{}
+}
+
+// customColumn is a column given with -o custom-columns
+type customColumn struct {
+	header string
+	path   string
+}
+
+// PrintTable prints the rows as a table for the text, wide and custom-columns formats, sorted by --sort-by and
+// without headers with --no-headers. details prints the text around the table and is skipped for custom columns and
+// with --no-headers. Other formats render the whole value as Print does.
+func PrintTable[T any](cmd *cobra.Command, value interface{}, rows []T, columns []Column[T], details func()) error {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+
+	name, argument, _ := strings.Cut(format, "=")
+	if name != constant.TEXT && name != Wide && name != CustomColumns {
+		return Print(format, value, nil)
+	}
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+
+	headers, data, err := buildTable(name, argument, sortBy, rows, columns)
+	if err != nil {
+		return exitcode.New(exitcode.Validation, err)
+	}
+	if name != CustomColumns && !noHeaders && details != nil {
+		details()
+	}
+	if noHeaders {
+		table.WriteWithoutHeaders(data)
+		return nil
+	}
+	table.Write(headers, data)
+	return nil
+}
+
+// buildTable returns the headers and cells of the table in the format, with the rows sorted by the JSONPath
+func buildTable[T any](format, argument, sortBy string, rows []T, columns []Column[T]) ([]string, [][]interface{}, error) {
+	rows, err := sortRows(rows, sortBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if format == CustomColumns {
+		customColumns, err := parseCustomColumns(argument)
+		if err != nil {
+			return nil, nil, err
+		}
+		headers := make([]string, 0, len(customColumns))
+		for _, column := range customColumns {
+			headers = append(headers, column.header)
+		}
+		var data [][]interface{}
+		for _, row := range rows {
+			generic, err := toGeneric(row)
+			if err != nil {
+				return nil, nil, err
+			}
+			cells := make([]interface{}, 0, len(customColumns))
+			for _, column := range customColumns {
+				cell, err := customColumnValue(column.path, generic)
+				if err != nil {
+					return nil, nil, err
+				}
+				cells = append(cells, cell)
+			}
+			data = append(data, cells)
+		}
+		return headers, data, nil
+	}
+
+	var headers []string
+	for _, column := range columns {
+		if !column.Wide || format == Wide {
+			headers = append(headers, column.Header)
+		}
+	}
+	var data [][]interface{}
+	for _, row := range rows {
+		var cells []interface{}
+		for _, column := range columns {
+			if !column.Wide || format == Wide {
+				cells = append(cells, column.Value(row))
+			}
+		}
+		data = append(data, cells)
+	}
+	return headers, data, nil
+}
+
+// parseCustomColumns parses HEADER:.path pairs separated by commas
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		header, path = strings.TrimSpace(header), jsonPathExpression(path)
+		if !found || header == "" || path == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:.path", part)
+		}
+		columns = append(columns, customColumn{header: header, path: path})
+	}
+	return columns, nil
+}
+
+// jsonPathExpression strips the optional braces around a JSONPath expression
+func jsonPathExpression(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	return path
+}
+
+func customColumnValue(path string, row interface{}) (string, error) {
+	values, err := selectJSONPath(path, row, row)
+	if err != nil {
+		return "", err
+	}
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		text, err := formatJSONPathValue(value)
+		if err != nil {
+			return "", err
+		}
+		formatted = append(formatted, text)
+	}
+	if len(formatted) == 0 {
+		return noneValue, nil
+	}
+	return strings.Join(formatted, ","), nil
+}
+
+// sortRows sorts the rows by the value at the JSONPath, comparing numbers numerically
+func sortRows[T any](rows []T, sortBy string) ([]T, error) {
+	sortBy = jsonPathExpression(sortBy)
+	if sortBy == "" {
+		return rows, nil
+	}
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		generic, err := toGeneric(row)
+		if err != nil {
+			return nil, err
+		}
+		values, err := selectJSONPath(sortBy, generic, generic)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --sort-by: %w", err)
+		}
+		if len(values) > 0 {
+			if keys[i], err = formatJSONPathValue(values[0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	indexes := make([]int, len(rows))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return lessValue(keys[indexes[i]], keys[indexes[j]])
+	})
+	sorted := make([]T, len(rows))
+	for i, index := range indexes {
+		sorted[i] = rows[index]
+	}
+	return sorted, nil
+}
+
+func lessValue(a, b string) bool {
+	numberA, errA := strconv.ParseFloat(a, 64)
+	numberB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return numberA < numberB
+	}
+	return a < b
+}
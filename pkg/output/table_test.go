@@ -0,0 +1,82 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRow struct {
+	Name     string `json:"name"`
+	Owner    string `json:"owner,omitempty"`
+	Replicas int    `json:"replicas"`
+}
+
+var testColumns = []Column[testRow]{
+	{Header: "NAME", Value: func(row testRow) interface{} { return row.Name }},
+	{Header: "REPLICAS", Value: func(row testRow) interface{} { return row.Replicas }},
+	{Header: "OWNER", Wide: true, Value: func(row testRow) interface{} { return row.Owner }},
+}
+
+func TestBuildTable(t *testing.T) {
+	rows := []testRow{
+		{Name: "orders", Owner: "alice", Replicas: 10},
+		{Name: "cart", Replicas: 9},
+		{Name: "search", Owner: "bob", Replicas: 2},
+	}
+	tests := []struct {
+		name            string
+		format          string
+		argument        string
+		sortBy          string
+		expectedHeaders []string
+		expectedData    [][]interface{}
+	}{
+		{
+			name:            "text",
+			format:          "text",
+			expectedHeaders: []string{"NAME", "REPLICAS"},
+			expectedData:    [][]interface{}{{"orders", 10}, {"cart", 9}, {"search", 2}},
+		},
+		{
+			name:            "wide sorted by name",
+			format:          Wide,
+			sortBy:          ".name",
+			expectedHeaders: []string{"NAME", "REPLICAS", "OWNER"},
+			expectedData:    [][]interface{}{{"cart", 9, ""}, {"orders", 10, "alice"}, {"search", 2, "bob"}},
+		},
+		{
+			name:            "sorted numerically",
+			format:          "text",
+			sortBy:          "{.replicas}",
+			expectedHeaders: []string{"NAME", "REPLICAS"},
+			expectedData:    [][]interface{}{{"search", 2}, {"cart", 9}, {"orders", 10}},
+		},
+		{
+			name:            "custom columns",
+			format:          CustomColumns,
+			argument:        "SERVICE:.name,OWNER:{.owner}",
+			expectedHeaders: []string{"SERVICE", "OWNER"},
+			expectedData:    [][]interface{}{{"orders", "alice"}, {"cart", noneValue}, {"search", "bob"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, data, err := buildTable(tt.format, tt.argument, tt.sortBy, rows, testColumns)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHeaders, headers)
+			assert.Equal(t, tt.expectedData, data)
+		})
+	}
+}
+
+func TestBuildTableErrors(t *testing.T) {
+	rows := []testRow{{Name: "orders"}}
+
+	_, _, err := buildTable(CustomColumns, "NAME", "", rows, testColumns)
+	assert.ErrorContains(t, err, "expected HEADER:.path")
+	_, _, err = buildTable("text", "", ".name[x]", rows, testColumns)
+	assert.ErrorContains(t, err, "invalid --sort-by")
+}
@@ -0,0 +1,121 @@
+package retryable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Clock waits between retries, so that they can be tested without sleeping
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the clock of the system
+var SystemClock Clock = systemClock{}
+
+// Policy retries a failed call with an exponential backoff and jitter
+type Policy struct {
+	// MaxAttempts is the maximum number of calls, the first one included
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction of the backoff which is random, between 0 and 1
+	Jitter float64
+	// RetryableCodes are the gRPC codes which are retried, along with the errors marked as retryable
+	RetryableCodes []codes.Code
+	// OnRetry is called before waiting for the given retry, starting at 1
+	OnRetry func(retry int, err error, wait time.Duration)
+	Clock   Clock
+	// Random returns a number in [0, 1) used for the jitter
+	Random func() float64
+}
+
+// ExhaustedError is returned when every attempt of a call failed with a retryable error
+type ExhaustedError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable checks if the error is marked as retryable or has one of the retryable codes
+func (p Policy) Retryable(err error) bool {
+	var re Error
+	if errors.As(err, &re) {
+		return re.Retryable()
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range p.RetryableCodes {
+		if st.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the wait before the given retry, starting at 1
+func (p Policy) Backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(2, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		random := rand.Float64
+		if p.Random != nil {
+			random = p.Random
+		}
+		// Spread the wait over [backoff * (1 - jitter), backoff * (1 + jitter))
+		backoff *= 1 + p.Jitter*(2*random()-1)
+	}
+	return time.Duration(backoff)
+}
+
+// Do calls the function until it succeeds, fails with an error which isn't retryable or MaxAttempts is reached, in
+// which case an ExhaustedError is returned. It stops waiting when the context is done.
+func (p Policy) Do(ctx context.Context, call func() error) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || !p.Retryable(err) {
+			return err
+		}
+		if attempt >= p.MaxAttempts {
+			return &ExhaustedError{Attempts: attempt, Err: err}
+		}
+
+		wait := p.Backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-clock.After(wait):
+		}
+	}
+}
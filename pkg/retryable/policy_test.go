@@ -0,0 +1,115 @@
+package retryable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClock records the waits and returns at once
+type fakeClock struct {
+	waits []time.Duration
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func testPolicy(clock Clock) Policy {
+	return Policy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     3 * time.Second,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+		Clock:          clock,
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	tests := []struct {
+		name     string
+		errors   []error
+		calls    int
+		waits    []time.Duration
+		expected error
+	}{
+		{name: "success", errors: []error{nil}, calls: 1},
+		{name: "retried until success", errors: []error{unavailable, unavailable, nil}, calls: 3, waits: []time.Duration{time.Second, 2 * time.Second}},
+		{name: "not retryable", errors: []error{status.Error(codes.NotFound, "not found")}, calls: 1, expected: status.Error(codes.NotFound, "not found")},
+		{name: "marked retryable", errors: []error{NewRetryableError(io.EOF, true), nil}, calls: 2, waits: []time.Duration{time.Second}},
+		{name: "marked not retryable", errors: []error{NewRetryableError(unavailable, false)}, calls: 1, expected: NewRetryableError(unavailable, false)},
+		{
+			name:     "exhausted",
+			errors:   []error{unavailable, unavailable, unavailable, unavailable},
+			calls:    4,
+			waits:    []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			expected: &ExhaustedError{Attempts: 4, Err: unavailable},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{}
+			policy := testPolicy(clock)
+			var retries []int
+			policy.OnRetry = func(retry int, err error, wait time.Duration) { retries = append(retries, retry) }
+
+			calls := 0
+			err := policy.Do(context.Background(), func() error {
+				err := tt.errors[calls]
+				calls++
+				return err
+			})
+			assert.Equal(t, tt.expected, err)
+			assert.Equal(t, tt.calls, calls)
+			assert.Equal(t, tt.waits, clock.waits)
+			assert.Len(t, retries, len(tt.waits))
+		})
+	}
+}
+
+func TestPolicyDoCancelled(t *testing.T) {
+	policy := testPolicy(SystemClock)
+	policy.InitialBackoff = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	err := policy.Do(ctx, func() error { return unavailable })
+	assert.Equal(t, unavailable, err)
+}
+
+func TestPolicyBackoffJitter(t *testing.T) {
+	policy := testPolicy(nil)
+	policy.Jitter = 0.5
+	tests := []struct {
+		random   float64
+		expected time.Duration
+	}{
+		{random: 0, expected: 1 * time.Second},
+		{random: 0.5, expected: 2 * time.Second},
+		{random: 0.75, expected: 2500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		policy.Random = func() float64 { return tt.random }
+		assert.Equal(t, tt.expected, policy.Backoff(2))
+	}
+}
+
+func TestExhaustedError(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	err := error(&ExhaustedError{Attempts: 3, Err: unavailable})
+	require.ErrorIs(t, err, unavailable)
+	assert.Equal(t, "giving up after 3 attempts: rpc error: code = Unavailable desc = unavailable", err.Error())
+	assert.False(t, errors.Is(err, io.EOF))
+}
@@ -11,6 +11,11 @@ func (w Error) Error() string {
 	return w.err.Error()
 }
 
+// Unwrap returns the error which may be retried.
+func (w Error) Unwrap() error {
+	return w.err
+}
+
 // Retryable checks if the error is retryable.
 func (w Error) Retryable() bool {
 	return w.retryable
@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -18,8 +19,13 @@ func setDefaultConfig(table *tablewriter.Table) {
 
 // Write : write provided input as tabular format
 func Write(headers []string, data [][]interface{}) {
+	Fprint(os.Stdout, headers, data)
+}
 
-	table := tablewriter.NewWriter(os.Stdout)
+// Fprint : write provided input as tabular format to the writer
+func Fprint(w io.Writer, headers []string, data [][]interface{}) {
+
+	table := tablewriter.NewWriter(w)
 
 	// table properties
 	setDefaultConfig(table)
@@ -31,6 +37,19 @@ func Write(headers []string, data [][]interface{}) {
 	//table data
 	table.SetHeader(headers)
 	table.SetHeaderColor(allHeaderColors...)
+	appendRows(table, data)
+	table.Render()
+}
+
+// WriteWithoutHeaders : write provided input as tabular format without the header row, e.g. for awk
+func WriteWithoutHeaders(data [][]interface{}) {
+	table := tablewriter.NewWriter(os.Stdout)
+	setDefaultConfig(table)
+	appendRows(table, data)
+	table.Render()
+}
+
+func appendRows(table *tablewriter.Table, data [][]interface{}) {
 	for _, row := range data {
 		s := make([]string, len(row))
 		for i, v := range row {
@@ -38,5 +57,4 @@ func Write(headers []string, data [][]interface{}) {
 		}
 		table.Append(s)
 	}
-	table.Render()
 }
@@ -0,0 +1,94 @@
+// Package tlsconfig builds the TLS configuration used to connect to the backend from the profile settings
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+)
+
+// versions are the accepted minimum TLS versions
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseVersion returns the TLS version, 1.2 when empty
+func ParseVersion(version string) (uint16, error) {
+	if version == "" {
+		return tls.VersionTLS12, nil
+	}
+	parsed, ok := versions[version]
+	if !ok {
+		return 0, exitcode.Errorf(exitcode.Validation, "invalid minimum TLS version %q: expected 1.2 or 1.3", version)
+	}
+	return parsed, nil
+}
+
+// ServerName returns the name the certificate of the backend is verified against: the override of the profile, or
+// else the host of the backend address
+func ServerName(config *configuration.Configuration) string {
+	if config.TLSServerName != "" {
+		return config.TLSServerName
+	}
+	host, _, err := net.SplitHostPort(config.BackendAddress)
+	if err != nil {
+		// The address has no port
+		return config.BackendAddress
+	}
+	return host
+}
+
+// SkipsVerification checks if the certificate of the backend is not verified: insecure is set and neither a CA
+// bundle nor a server name override asks for verification
+func SkipsVerification(config *configuration.Configuration) bool {
+	return config.Insecure && config.CACert == "" && config.TLSServerName == ""
+}
+
+// New returns the TLS configuration of the profile, reading its CA bundle and client certificate. It must not be
+// used for plaintext profiles.
+func New(config *configuration.Configuration) (*tls.Config, error) {
+	minVersion, err := ParseVersion(config.MinTLSVersion)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         ServerName(config),
+		MinVersion:         minVersion,
+		InsecureSkipVerify: SkipsVerification(config),
+	}
+
+	if config.CACert != "" {
+		pem, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, exitcode.Errorf(exitcode.Validation, "unable to read CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, exitcode.Errorf(exitcode.Validation, "no PEM certificate found in CA certificate %s", config.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (config.ClientCert == "") != (config.ClientKey == "") {
+		return nil, exitcode.Errorf(exitcode.Validation, "client_cert and client_key must be set together")
+	}
+	if config.ClientCert != "" {
+		certificate, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, exitcode.Errorf(exitcode.Validation, "unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// InsecureWarning is shown whenever certificate verification is disabled
+func InsecureWarning(config *configuration.Configuration) string {
+	return fmt.Sprintf("TLS certificate verification is DISABLED for %s (insecure = true): the connection and the access token can be intercepted. Set insecure to false, using ca_cert for a private CA", config.BackendAddress)
+}
@@ -0,0 +1,133 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dream-horizon-org/odin/api/configuration"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate and its key as PEM files, returning their paths
+func writeCertificate(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   configuration.Configuration
+		expected string
+	}{
+		{name: "host and port", config: configuration.Configuration{BackendAddress: "odin.example.com:443"}, expected: "odin.example.com"},
+		{name: "no port", config: configuration.Configuration{BackendAddress: "odin.example.com"}, expected: "odin.example.com"},
+		{name: "IPv6", config: configuration.Configuration{BackendAddress: "[2001:db8::1]:443"}, expected: "2001:db8::1"},
+		{name: "override", config: configuration.Configuration{BackendAddress: "10.0.0.1:443", TLSServerName: "odin.internal"}, expected: "odin.internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ServerName(&tt.config))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeCertificate(t, dir, "ca")
+	clientCert, clientKey := writeCertificate(t, dir, "client")
+	notPEM := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+	config, err := New(&configuration.Configuration{
+		BackendAddress: "odin.example.com:443",
+		CACert:         caFile,
+		ClientCert:     clientCert,
+		ClientKey:      clientKey,
+		MinTLSVersion:  "1.3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "odin.example.com", config.ServerName)
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	assert.NotNil(t, config.RootCAs)
+	assert.Len(t, config.Certificates, 1)
+	assert.False(t, config.InsecureSkipVerify)
+
+	config, err = New(&configuration.Configuration{BackendAddress: "odin.example.com:443", Insecure: true})
+	require.NoError(t, err)
+	assert.Nil(t, config.RootCAs)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.True(t, config.InsecureSkipVerify)
+
+	// A CA bundle turns the verification on even when insecure is set
+	config, err = New(&configuration.Configuration{BackendAddress: "odin.example.com:443", Insecure: true, CACert: caFile})
+	require.NoError(t, err)
+	assert.False(t, config.InsecureSkipVerify)
+
+	invalid := []struct {
+		name        string
+		config      configuration.Configuration
+		errContains string
+	}{
+		{name: "missing CA", config: configuration.Configuration{CACert: filepath.Join(dir, "missing.pem")}, errContains: "unable to read CA certificate"},
+		{name: "CA without certificate", config: configuration.Configuration{CACert: notPEM}, errContains: "no PEM certificate found"},
+		{name: "certificate without key", config: configuration.Configuration{ClientCert: clientCert}, errContains: "must be set together"},
+		{name: "mismatched key", config: configuration.Configuration{ClientCert: clientCert, ClientKey: caFile}, errContains: "unable to load client certificate"},
+		{name: "TLS version", config: configuration.Configuration{MinTLSVersion: "1.0"}, errContains: "expected 1.2 or 1.3"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&tt.config)
+			assert.ErrorContains(t, err, tt.errContains)
+			assert.Equal(t, exitcode.Validation, exitcode.FromError(err))
+		})
+	}
+}
+
+func TestSkipsVerification(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   configuration.Configuration
+		expected bool
+	}{
+		{name: "verified", config: configuration.Configuration{}},
+		{name: "insecure", config: configuration.Configuration{Insecure: true}, expected: true},
+		{name: "insecure with CA", config: configuration.Configuration{Insecure: true, CACert: "ca.pem"}},
+		{name: "insecure with server name", config: configuration.Configuration{Insecure: true, TLSServerName: "odin.internal"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SkipsVerification(&tt.config))
+		})
+	}
+}
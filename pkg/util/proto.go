@@ -0,0 +1,320 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	yamlProvider "gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// FileError describes a problem found at a specific location of a parsed file
+type FileError struct {
+	File    string
+	Line    int
+	Path    string
+	Message string
+}
+
+// Error formats the error as file:line: path: message
+func (e *FileError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	}
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %s", location, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, e.Path, e.Message)
+}
+
+// ParseProtoFile parses a json or yaml file into the given proto message using protojson semantics
+func ParseProtoFile(filePath string, message proto.Message) error {
+	node, err := readNode(filePath)
+	if err != nil {
+		return err
+	}
+	if errs := checkMessage(filePath, node, message.ProtoReflect().Descriptor(), "$"); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return unmarshalNode(filePath, node, message)
+}
+
+// ParseProtoListFile parses a json or yaml file holding a list of proto messages using protojson semantics
+func ParseProtoListFile[T proto.Message](filePath string, newMessage func() T) ([]T, error) {
+	node, err := readNode(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind != yamlProvider.SequenceNode {
+		return nil, &FileError{File: filePath, Line: node.Line, Path: "$", Message: "expected a list"}
+	}
+
+	descriptor := newMessage().ProtoReflect().Descriptor()
+	var errs []error
+	for i, item := range node.Content {
+		errs = append(errs, checkMessage(filePath, item, descriptor, fmt.Sprintf("$[%d]", i))...)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	messages := make([]T, 0, len(node.Content))
+	for _, item := range node.Content {
+		message := newMessage()
+		if err := unmarshalNode(filePath, item, message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// detectFormat detects the file format by extension, falling back to sniffing the content
+func detectFormat(filePath string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	}
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return formatJSON
+	}
+	return formatYAML
+}
+
+// readNode reads a json or yaml file into a yaml node tree which keeps line information
+func readNode(filePath string) (*yamlProvider.Node, error) {
+	if len(filePath) == 0 {
+		return nil, errors.New("filepath cannot be empty")
+	}
+	content, err := Read(filePath)
+	if err != nil {
+		return nil, errors.New("Error reading file: " + err.Error())
+	}
+
+	if detectFormat(filePath, content) == formatJSON {
+		return jsonToNode(filePath, content)
+	}
+
+	var document yamlProvider.Node
+	if err := yamlProvider.Unmarshal(content, &document); err != nil {
+		return nil, &FileError{File: filePath, Message: "unable to parse YAML: " + err.Error()}
+	}
+	if len(document.Content) == 0 {
+		return nil, &FileError{File: filePath, Message: "file is empty"}
+	}
+	return document.Content[0], nil
+}
+
+// jsonToNode converts json content into a yaml node tree, tracking the line of every value
+func jsonToNode(filePath string, content []byte) (*yamlProvider.Node, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	lineAt := func(offset int64) int {
+		return bytes.Count(content[:offset], []byte("\n")) + 1
+	}
+	syntaxError := func(err error) error {
+		line := lineAt(decoder.InputOffset())
+		var jsonSyntaxError *json.SyntaxError
+		if errors.As(err, &jsonSyntaxError) {
+			line = lineAt(jsonSyntaxError.Offset)
+		}
+		return &FileError{File: filePath, Line: line, Message: "unable to parse JSON: " + err.Error()}
+	}
+
+	var parse func() (*yamlProvider.Node, error)
+	parse = func() (*yamlProvider.Node, error) {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, syntaxError(err)
+		}
+		node := &yamlProvider.Node{Line: lineAt(decoder.InputOffset())}
+		switch value := token.(type) {
+		case json.Delim:
+			if value == '{' {
+				node.Kind, node.Tag = yamlProvider.MappingNode, "!!map"
+			} else {
+				node.Kind, node.Tag = yamlProvider.SequenceNode, "!!seq"
+			}
+			for decoder.More() {
+				if node.Kind == yamlProvider.MappingNode {
+					key, err := parse()
+					if err != nil {
+						return nil, err
+					}
+					node.Content = append(node.Content, key)
+				}
+				child, err := parse()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, child)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, syntaxError(err)
+			}
+		case string:
+			node.Kind, node.Tag, node.Value = yamlProvider.ScalarNode, "!!str", value
+		case json.Number:
+			node.Kind, node.Tag, node.Value = yamlProvider.ScalarNode, "!!int", value.String()
+			if _, err := strconv.ParseInt(value.String(), 10, 64); err != nil {
+				node.Tag = "!!float"
+			}
+		case bool:
+			node.Kind, node.Tag, node.Value = yamlProvider.ScalarNode, "!!bool", strconv.FormatBool(value)
+		case nil:
+			node.Kind, node.Tag, node.Value = yamlProvider.ScalarNode, "!!null", "null"
+		}
+		return node, nil
+	}
+
+	node, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, &FileError{File: filePath, Line: lineAt(decoder.InputOffset()), Message: "unable to parse JSON: unexpected data after top-level value"}
+	}
+	return node, nil
+}
+
+// unmarshalNode decodes a yaml node into the proto message through its json representation
+func unmarshalNode(filePath string, node *yamlProvider.Node, message proto.Message) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return &FileError{File: filePath, Line: node.Line, Message: err.Error()}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return &FileError{File: filePath, Line: node.Line, Message: err.Error()}
+	}
+	if err := protojson.Unmarshal(data, message); err != nil {
+		return &FileError{File: filePath, Line: node.Line, Message: err.Error()}
+	}
+	return nil
+}
+
+func resolveAlias(node *yamlProvider.Node) *yamlProvider.Node {
+	for node.Kind == yamlProvider.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func isNull(node *yamlProvider.Node) bool {
+	return node.Kind == yamlProvider.ScalarNode && node.ShortTag() == "!!null"
+}
+
+// checkMessage validates a node against a message descriptor and reports every problem with its line and path
+func checkMessage(filePath string, node *yamlProvider.Node, descriptor protoreflect.MessageDescriptor, path string) []error {
+	node = resolveAlias(node)
+	// well known types such as Struct and Timestamp are validated by protojson itself
+	if isNull(node) || strings.HasPrefix(string(descriptor.FullName()), "google.protobuf.") {
+		return nil
+	}
+	if node.Kind != yamlProvider.MappingNode {
+		return []error{&FileError{File: filePath, Line: node.Line, Path: path, Message: "expected an object"}}
+	}
+
+	var errs []error
+	fields := descriptor.Fields()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "<<" {
+			continue
+		}
+		field := fields.ByJSONName(key.Value)
+		if field == nil {
+			field = fields.ByName(protoreflect.Name(key.Value))
+		}
+		fieldPath := path + "." + key.Value
+		if field == nil {
+			errs = append(errs, &FileError{File: filePath, Line: key.Line, Path: fieldPath, Message: "unknown field"})
+			continue
+		}
+		errs = append(errs, checkField(filePath, value, field, fieldPath)...)
+	}
+	return errs
+}
+
+func checkField(filePath string, node *yamlProvider.Node, field protoreflect.FieldDescriptor, path string) []error {
+	node = resolveAlias(node)
+	if isNull(node) {
+		return nil
+	}
+	var errs []error
+	switch {
+	case field.IsMap():
+		if node.Kind != yamlProvider.MappingNode {
+			return []error{&FileError{File: filePath, Line: node.Line, Path: path, Message: "expected an object"}}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			errs = append(errs, checkValue(filePath, node.Content[i+1], field.MapValue(), path+"."+node.Content[i].Value)...)
+		}
+	case field.IsList():
+		if node.Kind != yamlProvider.SequenceNode {
+			return []error{&FileError{File: filePath, Line: node.Line, Path: path, Message: "expected a list"}}
+		}
+		for i, item := range node.Content {
+			errs = append(errs, checkValue(filePath, item, field, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	default:
+		errs = checkValue(filePath, node, field, path)
+	}
+	return errs
+}
+
+func checkValue(filePath string, node *yamlProvider.Node, field protoreflect.FieldDescriptor, path string) []error {
+	node = resolveAlias(node)
+	if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		return checkMessage(filePath, node, field.Message(), path)
+	}
+	if isNull(node) {
+		return nil
+	}
+	if node.Kind != yamlProvider.ScalarNode {
+		return []error{&FileError{File: filePath, Line: node.Line, Path: path, Message: "expected a scalar value"}}
+	}
+
+	var expected string
+	tag := node.ShortTag()
+	switch field.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if tag != "!!str" {
+			expected = "a string"
+		}
+	case protoreflect.BoolKind:
+		if tag != "!!bool" {
+			expected = "a boolean"
+		}
+	case protoreflect.EnumKind:
+		if tag != "!!str" && tag != "!!int" {
+			expected = "an enum name or number"
+		}
+	default:
+		if tag != "!!int" && tag != "!!float" && tag != "!!str" {
+			expected = "a number"
+		}
+	}
+	if expected != "" {
+		return []error{&FileError{File: filePath, Line: node.Line, Path: path, Message: fmt.Sprintf("expected %s, got %q", expected, node.Value)}}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	serviceDto "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/dto/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTempFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestParseProtoFile(t *testing.T) {
+	jsonDefinition := `{
+  "name": "orders",
+  "version": "1.0.0",
+  "components": [
+    {"name": "db", "type": "rds", "dependsOn": ["cache"], "config": {"size": 10}}
+  ]
+}`
+	yamlDefinition := `name: orders
+version: 1.0.0
+components:
+  - name: db
+    type: rds
+    depends_on: [cache]
+    config:
+      size: 10
+`
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{name: "json by extension", fileName: "definition.json", content: jsonDefinition},
+		{name: "yaml by extension", fileName: "definition.yaml", content: yamlDefinition},
+		{name: "json by content", fileName: "definition", content: jsonDefinition},
+		{name: "yaml by content", fileName: "definition", content: yamlDefinition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var definition serviceDto.ServiceDefinition
+			require.NoError(t, ParseProtoFile(writeTempFile(t, tt.fileName, tt.content), &definition))
+
+			assert.Equal(t, "orders", definition.GetName())
+			assert.Equal(t, "1.0.0", definition.GetVersion())
+			require.Len(t, definition.GetComponents(), 1)
+			assert.Equal(t, []string{"cache"}, definition.GetComponents()[0].GetDependsOn())
+			assert.Equal(t, float64(10), definition.GetComponents()[0].GetConfig().AsMap()["size"])
+		})
+	}
+}
+
+func TestParseProtoFileErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		content     string
+		errContains []string
+	}{
+		{
+			name:        "unknown field in yaml",
+			fileName:    "definition.yaml",
+			content:     "name: orders\ncomponents:\n  - name: db\n    flavor: aws\n",
+			errContains: []string{"definition.yaml:4: $.components[0].flavor: unknown field"},
+		},
+		{
+			name:        "unknown field in json",
+			fileName:    "definition.json",
+			content:     "{\n  \"name\": \"orders\",\n  \"owner\": \"me\"\n}",
+			errContains: []string{"definition.json:3: $.owner: unknown field"},
+		},
+		{
+			name:        "wrong scalar type",
+			fileName:    "definition.yaml",
+			content:     "name: orders\nversion: 1.0\n",
+			errContains: []string{"definition.yaml:2: $.version: expected a string"},
+		},
+		{
+			name:     "every violation is reported",
+			fileName: "definition.yaml",
+			content:  "name: [orders]\ncomponents: db\n",
+			errContains: []string{
+				"definition.yaml:1: $.name: expected a scalar value",
+				"definition.yaml:2: $.components: expected a list",
+			},
+		},
+		{
+			name:        "json syntax error",
+			fileName:    "definition.json",
+			content:     "{\n  \"name\": \"orders\",\n  \"version\" \"1\"\n}",
+			errContains: []string{"definition.json:3: unable to parse JSON"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempFile(t, tt.fileName, tt.content)
+			var definition serviceDto.ServiceDefinition
+			err := ParseProtoFile(path, &definition)
+			require.Error(t, err)
+			for _, expected := range tt.errContains {
+				assert.Contains(t, err.Error(), filepath.Join(filepath.Dir(path), expected))
+			}
+		})
+	}
+}
+
+func TestParseProtoListFile(t *testing.T) {
+	path := writeTempFile(t, "provisioning.yaml", `- component_name: db
+  deployment_type: rds
+  params:
+    instance: small
+- componentName: cache
+  deploymentType: elasticache
+`)
+	configs, err := ParseProtoListFile(path, func() *serviceDto.ComponentProvisioningConfig {
+		return &serviceDto.ComponentProvisioningConfig{}
+	})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "db", configs[0].GetComponentName())
+	assert.Equal(t, "small", configs[0].GetParams().AsMap()["instance"])
+	assert.Equal(t, "elasticache", configs[1].GetDeploymentType())
+
+	_, err = ParseProtoListFile(writeTempFile(t, "provisioning.json", `{"component_name": "db"}`), func() *serviceDto.ComponentProvisioningConfig {
+		return &serviceDto.ComponentProvisioningConfig{}
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$: expected a list")
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/dream-horizon-org/odin/internal/ui"
+	"github.com/dream-horizon-org/odin/pkg/exitcode"
 	v1 "github.com/dream-horizon-org/odin/proto/gen/go/dream11/od/service/v1"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -160,16 +161,18 @@ func ConvertJSONToYAML(jsonStr string) (string, error) {
 	return string(yamlData), nil
 }
 
-// AskForConfirmation asks for confirmation before proceeding with the operation
-func AskForConfirmation(expectedValue, consentMessage string) {
+// AskForConfirmation asks for confirmation before proceeding with the operation, it returns an error when the
+// operation is aborted
+func AskForConfirmation(expectedValue, consentMessage string) error {
 	inputHandler := ui.Input{}
 	val, err := inputHandler.Ask(consentMessage)
 	if err != nil {
-		log.Fatal(err.Error())
+		return err
 	}
 	if val != expectedValue {
-		log.Fatal(fmt.Errorf("invalid input, aborting the operation"))
+		return exitcode.Errorf(exitcode.Aborted, "invalid input, aborting the operation")
 	}
+	return nil
 }
 
 // IsRetryable checks if the error is retryable
@@ -183,13 +186,3 @@ func IsRetryable(err error) bool {
 	st, ok := status.FromError(err)
 	return ok && (st.Code() == codes.Unavailable || (st.Code() == codes.Internal && strings.Contains(st.Message(), "RST_STREAM")))
 }
-
-// LogGrpcError log grpc error
-func LogGrpcError(err error, prefix string) {
-	st, ok := status.FromError(err)
-	if ok {
-		log.Error(prefix + st.Message())
-	} else {
-		log.Error(prefix + err.Error())
-	}
-}